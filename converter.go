@@ -0,0 +1,145 @@
+package godence
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/onflow/cadence"
+)
+
+// EncoderFunc Convert go value of the registered type to cadence value.
+type EncoderFunc func(value any) (cadence.Value, error)
+
+// DecoderFunc Convert cadence value to go value, dist is a pointer to the registered type.
+// The cadence value may be an optional if the registered type is not a pointer.
+type DecoderFunc func(value cadence.Value, dist any) error
+
+// converters. registered functions keyed by go type, safe for concurrent use.
+type converters struct {
+	funcs sync.Map // reflect.Type -> EncoderFunc or DecoderFunc
+}
+
+// store. nil function removes the registered one.
+func (c *converters) store(t reflect.Type, fn any) {
+	if reflect.ValueOf(fn).IsNil() {
+		c.funcs.Delete(t)
+		return
+	}
+	c.funcs.Store(t, fn)
+}
+
+func (c *converters) load(t reflect.Type) (any, bool) {
+	return c.funcs.Load(t)
+}
+
+// global converters, used by all encoders and decoders.
+var (
+	globalEncoders converters
+	globalDecoders converters
+)
+
+// RegisterEncoder Register a function to convert go values of type t to cadence, used by all encoders,
+// including ToCadence, ToCadenceAs and ToCadenceArguments. Register nil to remove it.
+// It is useful for third-party types that cannot implement CadenceMarshaler, e.g. time.Time.
+func RegisterEncoder(t reflect.Type, fn EncoderFunc) {
+	globalEncoders.store(t, fn)
+}
+
+// RegisterDecoder Register a function to convert cadence values to go type t, used by all decoders,
+// including ToGo and Decode. Register nil to remove it.
+// It is useful for third-party types that cannot implement CadenceUnmarshaler, e.g. time.Time.
+func RegisterDecoder(t reflect.Type, fn DecoderFunc) {
+	globalDecoders.store(t, fn)
+}
+
+// Encoder Convert go values to cadence, with its own options and registered functions.
+// Safe for concurrent use, create one by NewEncoder.
+type Encoder struct {
+	options
+	encoders converters
+}
+
+// NewEncoder Create an encoder with options, it converts the same as ToCadence without options.
+// e.g. NewEncoder(WithNaming(NamingLowerCamel), WithMapOrder(MapOrderSorted))
+func NewEncoder(opts ...Option) *Encoder {
+	e := &Encoder{}
+	for _, opt := range opts {
+		opt(&e.options)
+	}
+	for t, fn := range e.encoderFuncs {
+		e.RegisterEncoder(t, fn)
+	}
+	e.encoderFuncs = nil
+	return e
+}
+
+// RegisterEncoder The same as RegisterEncoder, but only used by this encoder.
+// It takes precedence over the global one.
+func (e *Encoder) RegisterEncoder(t reflect.Type, fn EncoderFunc) {
+	e.encoders.store(t, fn)
+}
+
+// encoderOf. registered function of type of value, the encoder's own first, then the global one.
+func (e *Encoder) encoderOf(value any) (EncoderFunc, bool) {
+	if value == nil {
+		return nil, false
+	}
+	t := reflect.TypeOf(value)
+	if fn, ok := e.encoders.load(t); ok {
+		return fn.(EncoderFunc), true
+	}
+	if fn, ok := globalEncoders.load(t); ok {
+		return fn.(EncoderFunc), true
+	}
+	return nil, false
+}
+
+// Decoder Convert cadence values to go, with its own options and registered functions.
+// Safe for concurrent use, create one by NewDecoder.
+type Decoder struct {
+	options
+	decoders converters
+}
+
+// NewDecoder Create a decoder with options, it converts the same as ToGo without options.
+// e.g. NewDecoder(WithStrict(), WithIntegerPolicy(IntegerExact))
+func NewDecoder(opts ...Option) *Decoder {
+	d := &Decoder{}
+	for _, opt := range opts {
+		opt(&d.options)
+	}
+	for t, fn := range d.decoderFuncs {
+		d.RegisterDecoder(t, fn)
+	}
+	d.decoderFuncs = nil
+	return d
+}
+
+// RegisterDecoder The same as RegisterDecoder, but only used by this decoder.
+// It takes precedence over the global one.
+func (d *Decoder) RegisterDecoder(t reflect.Type, fn DecoderFunc) {
+	d.decoders.store(t, fn)
+}
+
+// decoderOf. registered function of go type t, the decoder's own first, then the global one.
+func (d *Decoder) decoderOf(t reflect.Type) (DecoderFunc, bool) {
+	if fn, ok := d.decoders.load(t); ok {
+		return fn.(DecoderFunc), true
+	}
+	if fn, ok := globalDecoders.load(t); ok {
+		return fn.(DecoderFunc), true
+	}
+	return nil, false
+}
+
+// hasDecoder. check if go type t has a registered function.
+func (d *Decoder) hasDecoder(t reflect.Type) bool {
+	_, ok := d.decoderOf(t)
+	return ok
+}
+
+// default encoder and decoder, used by ToCadence, ToGo and others.
+var (
+	defaultEncoder = NewEncoder()
+	defaultDecoder = NewDecoder()
+)
@@ -0,0 +1,261 @@
+package godence
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// encodeTime. time.Time to UFix64 unix timestamp, the same as block timestamp.
+func encodeTime(value any) (cadence.Value, error) {
+	return cadence.UFix64(value.(time.Time).UnixNano() / 10), nil
+}
+
+// decodeTime. UFix64 unix timestamp to time.Time in UTC.
+func decodeTime(value cadence.Value, dist any) error {
+	if optional, ok := value.(cadence.Optional); ok && optional.Value != nil {
+		value = optional.Value
+	}
+	timestamp, ok := value.(cadence.UFix64)
+	if !ok {
+		return fmt.Errorf("timestamp should be UFix64, got %s", value.Type().ID())
+	}
+	*dist.(*time.Time) = time.Unix(int64(timestamp/1e8), int64(timestamp%1e8)*10).UTC()
+	return nil
+}
+
+func ExampleEncoder_RegisterEncoder() {
+	encoder := NewEncoder()
+	encoder.RegisterEncoder(timeType, encodeTime)
+	cadenceValue, err := encoder.Encode(time.Unix(1, 500000000))
+	fmt.Printf("type id: %s, value: %s, err: %v", cadenceValue.Type().ID(), cadenceValue, err)
+	//Output: type id: UFix64, value: 1.50000000, err: <nil>
+}
+
+func TestEncoderRegistry(t *testing.T) {
+	t.Run("registered type", func(t *testing.T) {
+		assert := assert.New(t)
+		encoder := NewEncoder()
+		encoder.RegisterEncoder(timeType, encodeTime)
+
+		cadenceValue, err := encoder.Encode(time.Unix(1, 500000000))
+		assert.NoError(err)
+		assert.Equal(cadence.UFix64(150000000), cadenceValue)
+
+		cadenceValue, err = encoder.Encode([]time.Time{time.Unix(1, 0)})
+		assert.NoError(err)
+		assert.Equal(cadence.NewArray([]cadence.Value{cadence.UFix64(100000000)}), cadenceValue)
+
+		// not registered by the default encoder
+		_, err = ToCadence(time.Unix(1, 0))
+		assert.Error(err)
+	})
+
+	t.Run("EncodeAs checks the type", func(t *testing.T) {
+		type sale struct {
+			Price   UFix64    `godence:"price"`
+			EndTime time.Time `godence:"endTime"`
+		}
+		assert := assert.New(t)
+		encoder := NewEncoder()
+		encoder.RegisterEncoder(timeType, encodeTime)
+		typ := &cadence.StructType{
+			QualifiedIdentifier: "Sale",
+			Fields: []cadence.Field{
+				{Identifier: "price", Type: cadence.UFix64Type{}},
+				{Identifier: "endTime", Type: cadence.OptionalType{Type: cadence.UFix64Type{}}},
+			},
+		}
+
+		cadenceValue, err := encoder.EncodeAs(sale{Price: 100000000, EndTime: time.Unix(1, 0)}, typ)
+		assert.NoError(err)
+		assert.Equal(cadence.NewStruct([]cadence.Value{
+			cadence.UFix64(100000000),
+			cadence.NewOptional(cadence.UFix64(100000000)),
+		}).WithType(typ), cadenceValue)
+
+		_, err = encoder.EncodeAs(time.Unix(1, 0), cadence.Fix64Type{})
+		assert.EqualError(err, "cannot convert cadence.UFix64 to Fix64")
+	})
+
+	t.Run("takes precedence over marshaler", func(t *testing.T) {
+		assert := assert.New(t)
+		encoder := NewEncoder()
+		encoder.RegisterEncoder(reflect.TypeOf(tokenAmount("")), func(value any) (cadence.Value, error) {
+			return cadence.NewString(string(value.(tokenAmount)))
+		})
+
+		cadenceValue, err := encoder.Encode(tokenAmount("1.5"))
+		assert.NoError(err)
+		assert.Equal(cadence.String("1.5"), cadenceValue)
+	})
+
+	t.Run("global and instance", func(t *testing.T) {
+		assert := assert.New(t)
+		RegisterEncoder(timeType, encodeTime)
+		defer RegisterEncoder(timeType, nil)
+		encoder := NewEncoder()
+		encoder.RegisterEncoder(timeType, func(value any) (cadence.Value, error) {
+			return cadence.NewUInt64(uint64(value.(time.Time).Unix())), nil
+		})
+
+		cadenceValue, err := ToCadence(time.Unix(1, 0))
+		assert.NoError(err)
+		assert.Equal(cadence.UFix64(100000000), cadenceValue)
+
+		cadenceValue, err = encoder.Encode(time.Unix(1, 0))
+		assert.NoError(err)
+		assert.Equal(cadence.NewUInt64(1), cadenceValue)
+
+		// removed
+		encoder.RegisterEncoder(timeType, nil)
+		cadenceValue, err = encoder.Encode(time.Unix(1, 0))
+		assert.NoError(err)
+		assert.Equal(cadence.UFix64(100000000), cadenceValue)
+	})
+
+	t.Run("error has path", func(t *testing.T) {
+		assert := assert.New(t)
+		encoder := NewEncoder()
+		encoder.RegisterEncoder(timeType, func(value any) (cadence.Value, error) {
+			return nil, fmt.Errorf("time before 1970")
+		})
+
+		_, err := encoder.Encode(map[string]time.Time{"start": {}})
+		assert.EqualError(err, `["start"]: time before 1970`)
+	})
+}
+
+func TestDecoderRegistry(t *testing.T) {
+	t.Run("block timestamp", func(t *testing.T) {
+		assert := assert.New(t)
+		script := []byte(`pub fun main(): UFix64 { return getCurrentBlock().timestamp }`)
+		decoder := NewDecoder()
+		decoder.RegisterDecoder(timeType, decodeTime)
+
+		ret, err := flowCli.ExecuteScriptAtLatestBlock(context.Background(), script, nil)
+		assert.NoError(err)
+
+		var dist time.Time
+		assert.NoError(decoder.Decode(ret, &dist))
+		assert.False(dist.IsZero())
+	})
+
+	t.Run("registered type", func(t *testing.T) {
+		assert := assert.New(t)
+		decoder := NewDecoder()
+		decoder.RegisterDecoder(timeType, decodeTime)
+
+		var dist time.Time
+		assert.NoError(decoder.Decode(cadence.UFix64(150000000), &dist))
+		assert.Equal(time.Unix(1, 500000000).UTC(), dist)
+
+		var times []time.Time
+		assert.NoError(decoder.Decode(cadence.NewArray([]cadence.Value{cadence.UFix64(100000000)}), &times))
+		assert.Equal([]time.Time{time.Unix(1, 0).UTC()}, times)
+
+		// not registered by the default decoder
+		assert.Error(ToGo(cadence.UFix64(150000000), &dist))
+	})
+
+	t.Run("struct fields", func(t *testing.T) {
+		type sale struct {
+			Price   UFix64     `godence:"price"`
+			EndTime *time.Time `godence:"endTime"`
+		}
+		assert := assert.New(t)
+		decoder := NewDecoder()
+		decoder.RegisterDecoder(timeType, decodeTime)
+		typ := &cadence.StructType{
+			QualifiedIdentifier: "Sale",
+			Fields: []cadence.Field{
+				{Identifier: "price", Type: cadence.UFix64Type{}},
+				{Identifier: "endTime", Type: cadence.OptionalType{Type: cadence.UFix64Type{}}},
+			},
+		}
+
+		dist := sale{}
+		value := cadence.NewStruct([]cadence.Value{cadence.UFix64(100000000), cadence.NewOptional(cadence.UFix64(100000000))}).WithType(typ)
+		assert.NoError(decoder.Decode(value, &dist))
+		assert.Equal(time.Unix(1, 0).UTC(), *dist.EndTime)
+
+		value = cadence.NewStruct([]cadence.Value{cadence.UFix64(100000000), cadence.NewOptional(nil)}).WithType(typ)
+		assert.NoError(decoder.Decode(value, &dist))
+		assert.Nil(dist.EndTime)
+	})
+
+	t.Run("registered pointer type", func(t *testing.T) {
+		assert := assert.New(t)
+		decoder := NewDecoder()
+		// nil optional is zero time rather than nil pointer
+		decoder.RegisterDecoder(reflect.TypeOf(&time.Time{}), func(value cadence.Value, dist any) error {
+			ptr := dist.(**time.Time)
+			*ptr = &time.Time{}
+			if isNilOptional(value) {
+				return nil
+			}
+			return decodeTime(value, *ptr)
+		})
+
+		var dist []*time.Time
+		assert.NoError(decoder.Decode(cadence.NewArray([]cadence.Value{cadence.NewOptional(nil)}), &dist))
+		assert.Equal([]*time.Time{{}}, dist)
+	})
+
+	t.Run("global and instance", func(t *testing.T) {
+		assert := assert.New(t)
+		RegisterDecoder(timeType, decodeTime)
+		defer RegisterDecoder(timeType, nil)
+		decoder := NewDecoder()
+		decoder.RegisterDecoder(timeType, func(value cadence.Value, dist any) error {
+			*dist.(*time.Time) = time.Unix(int64(value.(cadence.UInt64)), 0).UTC()
+			return nil
+		})
+
+		var dist time.Time
+		assert.NoError(ToGo(cadence.UFix64(100000000), &dist))
+		assert.Equal(time.Unix(1, 0).UTC(), dist)
+
+		assert.NoError(decoder.Decode(cadence.NewUInt64(2), &dist))
+		assert.Equal(time.Unix(2, 0).UTC(), dist)
+	})
+
+	t.Run("error has path", func(t *testing.T) {
+		assert := assert.New(t)
+		decoder := NewDecoder()
+		decoder.RegisterDecoder(timeType, decodeTime)
+
+		var dist []time.Time
+		err := decoder.Decode(cadence.NewArray([]cadence.Value{cadence.String("LemonNeko")}), &dist)
+		assert.EqualError(err, "[0]: timestamp should be UFix64, got String")
+	})
+
+	t.Run("concurrent use", func(t *testing.T) {
+		assert := assert.New(t)
+		decoder := NewDecoder()
+		decoder.RegisterDecoder(timeType, decodeTime)
+
+		wg := sync.WaitGroup{}
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				var dist time.Time
+				assert.NoError(decoder.Decode(cadence.UFix64(100000000), &dist))
+			}()
+			go func() {
+				defer wg.Done()
+				decoder.RegisterDecoder(reflect.TypeOf(tokenAmount("")), nil)
+			}()
+		}
+		wg.Wait()
+	})
+}
@@ -0,0 +1,264 @@
+package godence
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/onflow/cadence"
+)
+
+// Kinds of error, use errors.Is to check the kind of error returned by ToGo, ToCadence and ToCadenceAs.
+// Use errors.As with *UnsupportedTypeError, *FieldNotFoundError, *TypeMismatchError,
+// *OverflowError, *InvalidDestinationError or *AmbiguousFieldError to get the details.
+var (
+	ErrUnsupportedType    = errors.New("unsupported type")
+	ErrFieldNotFound      = errors.New("field not found")
+	ErrTypeMismatch       = errors.New("type mismatch")
+	ErrOverflow           = errors.New("overflow")
+	ErrInvalidDestination = errors.New("invalid destination")
+	ErrAmbiguousField     = errors.New("ambiguous field")
+)
+
+// PathError Error with the path of cadence value which failed to convert, e.g. ManyType.p.myName or [0].myName.
+// Errors of the catalog above carry the path by themselves, so they are not wrapped by PathError.
+type PathError struct {
+	Path string
+	Err  error
+}
+
+func (e *PathError) Error() string {
+	return e.Path + ": " + e.Err.Error()
+}
+
+func (e *PathError) Unwrap() error {
+	return e.Err
+}
+
+// UnsupportedTypeError Go type cannot be converted to or from cadence at all.
+type UnsupportedTypeError struct {
+	// cadence type to convert from, nil if converting go value to cadence
+	CadenceType cadence.Type
+	GoType      reflect.Type
+	Path        string
+	// true if go struct type is not registered by RegisterStruct
+	unregistered bool
+	// true if the cadence type to convert to is nil, e.g. EncodeAs(value, nil)
+	nilType bool
+}
+
+func (e *UnsupportedTypeError) Error() string {
+	switch {
+	case e.nilType:
+		return prefixPath(e.Path, fmt.Sprintf("cannot convert %s to nil cadence type", goTypeName(e.GoType)))
+	case e.unregistered:
+		return prefixPath(e.Path, fmt.Sprintf("unregistered struct type: %s", goTypeName(e.GoType)))
+	case e.GoType == nil && e.CadenceType == nil:
+		return prefixPath(e.Path, "unsupport nil value, use cadence.NewOptional(nil) for cadence nil")
+	}
+	return prefixPath(e.Path, fmt.Sprintf("unsupport type: %s", goTypeName(e.GoType)))
+}
+
+func (e *UnsupportedTypeError) Is(target error) bool {
+	return target == ErrUnsupportedType
+}
+
+// FieldNotFoundError Field of cadence composite or go struct cannot be found.
+type FieldNotFoundError struct {
+	// field name in cadence
+	Field       string
+	CadenceType cadence.Type
+	GoType      reflect.Type
+	Path        string
+	// true if the field is missing in go struct
+	missingInGo bool
+}
+
+func (e *FieldNotFoundError) Error() string {
+	if e.missingInGo {
+		return prefixPath(e.Path, fmt.Sprintf("cannot find field named %s in go struct %s", e.Field, goTypeName(e.GoType)))
+	}
+	return prefixPath(e.Path, fmt.Sprintf("cannot find field named %s in %s", e.Field, typeIDOf(e.CadenceType)))
+}
+
+func (e *FieldNotFoundError) Is(target error) bool {
+	return target == ErrFieldNotFound
+}
+
+// TypeMismatchError Cadence type and go type cannot be converted to each other.
+type TypeMismatchError struct {
+	CadenceType cadence.Type
+	GoType      reflect.Type
+	Path        string
+	// true if converting go value to cadence
+	toCadence bool
+	// go type of cadence value, used if the value has no type, e.g. untyped array
+	valueKind string
+}
+
+func (e *TypeMismatchError) Error() string {
+	if e.toCadence {
+		return prefixPath(e.Path, fmt.Sprintf("cannot convert %s to %s", goTypeName(e.GoType), typeIDOf(e.CadenceType)))
+	}
+	from := typeIDOf(e.CadenceType)
+	if e.CadenceType == nil && e.valueKind != "" {
+		from = e.valueKind
+	}
+	return prefixPath(e.Path, fmt.Sprintf("cannot convert %s to %s", from, goTypeName(e.GoType)))
+}
+
+func (e *TypeMismatchError) Is(target error) bool {
+	return target == ErrTypeMismatch
+}
+
+// OverflowError Value is out of range of the type to convert to.
+type OverflowError struct {
+	// value in decimal string
+	Value       string
+	CadenceType cadence.Type
+	GoType      reflect.Type
+	Path        string
+	// true if converting go value to cadence
+	toCadence bool
+}
+
+func (e *OverflowError) Error() string {
+	if e.toCadence {
+		return prefixPath(e.Path, fmt.Sprintf("value %s out of range of %s", e.Value, typeIDOf(e.CadenceType)))
+	}
+	return prefixPath(e.Path, fmt.Sprintf("value %s out of range of %s", e.Value, goTypeName(e.GoType)))
+}
+
+func (e *OverflowError) Is(target error) bool {
+	return target == ErrOverflow
+}
+
+// InvalidDestinationError Param 2 of ToGo cannot be set, it should be a non-nil pointer or a non-nil map.
+type InvalidDestinationError struct {
+	GoType reflect.Type
+	Path   string
+}
+
+func (e *InvalidDestinationError) Error() string {
+	if e.GoType != nil && e.GoType.Kind() == reflect.Map {
+		return prefixPath(e.Path, fmt.Sprintf("cannot set entries to nil map %s, use pointer to map instead", e.GoType))
+	}
+	return prefixPath(e.Path, fmt.Sprintf("invalid destination %v, should be a non-nil pointer", e.GoType))
+}
+
+func (e *InvalidDestinationError) Is(target error) bool {
+	return target == ErrInvalidDestination
+}
+
+// AmbiguousFieldError Two fields of cadence composite or go struct match the same field under the naming strategy,
+// e.g. myName and MyName with NamingCaseInsensitive.
+type AmbiguousFieldError struct {
+	// names of the two fields, in cadence or in go
+	Fields      [2]string
+	CadenceType cadence.Type
+	GoType      reflect.Type
+	Path        string
+	// true if the fields are go fields
+	inGo bool
+}
+
+func (e *AmbiguousFieldError) Error() string {
+	if e.inGo {
+		return prefixPath(e.Path, fmt.Sprintf("ambiguous fields %s and %s in go struct %s", e.Fields[0], e.Fields[1], goTypeName(e.GoType)))
+	}
+	return prefixPath(e.Path, fmt.Sprintf("ambiguous fields %s and %s in %s", e.Fields[0], e.Fields[1], typeIDOf(e.CadenceType)))
+}
+
+func (e *AmbiguousFieldError) Is(target error) bool {
+	return target == ErrAmbiguousField
+}
+
+// typeIDOf. type id for error message, cadence type may be nil.
+func typeIDOf(typ cadence.Type) string {
+	if typ == nil {
+		return "<nil>"
+	}
+	return typ.ID()
+}
+
+// goTypeName. go type for error message, go type may be nil, e.g. type of nil value.
+func goTypeName(t reflect.Type) string {
+	if t == nil {
+		return "nil"
+	}
+	return t.String()
+}
+
+// prefixPath. prepend path to error message if any.
+func prefixPath(path string, msg string) string {
+	if path == "" {
+		return msg
+	}
+	return path + ": " + msg
+}
+
+// joinPath. join path segment and path of child, e.g. p and myName to p.myName, p and [0] to p[0].
+func joinPath(segment string, path string) string {
+	if path == "" {
+		return segment
+	}
+	if strings.HasPrefix(path, "[") {
+		return segment + path
+	}
+	return segment + "." + path
+}
+
+// errorPath. path of error, empty if error has no path.
+func errorPath(err error) string {
+	switch e := err.(type) {
+	case *PathError:
+		return e.Path
+	case *UnsupportedTypeError:
+		return e.Path
+	case *FieldNotFoundError:
+		return e.Path
+	case *TypeMismatchError:
+		return e.Path
+	case *OverflowError:
+		return e.Path
+	case *InvalidDestinationError:
+		return e.Path
+	case *AmbiguousFieldError:
+		return e.Path
+	}
+	return ""
+}
+
+// withPath. prepend a field name, [index] or [key] to path of error.
+func withPath(err error, segment string) error {
+	switch e := err.(type) {
+	case *PathError:
+		return &PathError{Path: joinPath(segment, e.Path), Err: e.Err}
+	case *UnsupportedTypeError:
+		c := *e
+		c.Path = joinPath(segment, e.Path)
+		return &c
+	case *FieldNotFoundError:
+		c := *e
+		c.Path = joinPath(segment, e.Path)
+		return &c
+	case *TypeMismatchError:
+		c := *e
+		c.Path = joinPath(segment, e.Path)
+		return &c
+	case *OverflowError:
+		c := *e
+		c.Path = joinPath(segment, e.Path)
+		return &c
+	case *InvalidDestinationError:
+		c := *e
+		c.Path = joinPath(segment, e.Path)
+		return &c
+	case *AmbiguousFieldError:
+		c := *e
+		c.Path = joinPath(segment, e.Path)
+		return &c
+	}
+	return &PathError{Path: segment, Err: err}
+}
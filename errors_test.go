@@ -0,0 +1,53 @@
+package godence
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleTypeMismatchError() {
+	var dist []string
+	err := ToGo(cadence.String("LemonNeko"), &dist)
+
+	var mismatchErr *TypeMismatchError
+	if errors.As(err, &mismatchErr) {
+		fmt.Printf("cadence type: %s, go type: %s", mismatchErr.CadenceType.ID(), mismatchErr.GoType)
+	}
+	//Output: cadence type: String, go type: []string
+}
+
+func TestWithPath(t *testing.T) {
+	t.Run("plain error", func(t *testing.T) {
+		assert := assert.New(t)
+		err := withPath(withPath(errors.New("LemonNeko"), "myName"), "p")
+		assert.EqualError(err, "p.myName: LemonNeko")
+		err = withPath(withPath(err, "[0]"), "ManyType")
+		assert.EqualError(err, "ManyType[0].p.myName: LemonNeko")
+	})
+
+	t.Run("typed error keeps its kind", func(t *testing.T) {
+		assert := assert.New(t)
+		goType := reflect.TypeOf("")
+		err := withPath(withPath(&TypeMismatchError{CadenceType: cadence.UInt8Type{}, GoType: goType}, "myName"), "[0]")
+		assert.EqualError(err, "[0].myName: cannot convert UInt8 to string")
+		assert.ErrorIs(err, ErrTypeMismatch)
+		assert.NotErrorIs(err, ErrOverflow)
+
+		var mismatchErr *TypeMismatchError
+		assert.ErrorAs(err, &mismatchErr)
+		assert.Equal("[0].myName", mismatchErr.Path)
+		assert.Equal(goType, mismatchErr.GoType)
+	})
+
+	t.Run("typed error wrapped by other error", func(t *testing.T) {
+		assert := assert.New(t)
+		err := withPath(fmt.Errorf("length 2: %w", &OverflowError{Value: "256", GoType: reflect.TypeOf(uint8(0))}), "p")
+		assert.EqualError(err, "p: length 2: value 256 out of range of uint8")
+		assert.ErrorIs(err, ErrOverflow)
+	})
+}
@@ -0,0 +1,192 @@
+package godence
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/parser2"
+)
+
+// fieldTag. options of godence tag, e.g. `godence:"amount,optional,type=UFix64"`.
+type fieldTag struct {
+	// cadence field name, empty to name by the naming strategy
+	name string
+	// `godence:"-"`, skip the field in both directions
+	skip bool
+	// missing cadence field is not an error
+	optional bool
+	// encode nil or zero value as cadence nil
+	omitEmpty bool
+	// cadence type to encode as and to check when decoding, nil if not specified
+	typ cadence.Type
+	// collect cadence fields without go field
+	remain bool
+}
+
+var (
+	cadenceValueType = reflect.TypeOf((*cadence.Value)(nil)).Elem()
+	anyType          = reflect.TypeOf((*any)(nil)).Elem()
+)
+
+// parseFieldTag. parse godence tag of a go struct field.
+func parseFieldTag(field reflect.StructField) (fieldTag, error) {
+	tagValue, ok := field.Tag.Lookup("godence")
+	if !ok {
+		return fieldTag{}, nil
+	}
+	if tagValue == "-" {
+		return fieldTag{skip: true}, nil
+	}
+	parts := strings.Split(tagValue, ",")
+	tag := fieldTag{name: parts[0]}
+	for _, option := range parts[1:] {
+		switch {
+		case option == "optional":
+			tag.optional = true
+		case option == "omitempty":
+			tag.omitEmpty = true
+		case option == "remain":
+			if !isRemainType(field.Type) {
+				return fieldTag{}, fmt.Errorf("invalid godence tag of field %s: remain field should be map[string]cadence.Value or map[string]any", field.Name)
+			}
+			tag.remain = true
+		case strings.HasPrefix(option, "type="):
+			typ, err := parseCadenceType(strings.TrimPrefix(option, "type="))
+			if err != nil {
+				return fieldTag{}, fmt.Errorf("invalid godence tag of field %s: %w", field.Name, err)
+			}
+			tag.typ = typ
+		default:
+			return fieldTag{}, fmt.Errorf("invalid godence tag of field %s: unknown option %q", field.Name, option)
+		}
+	}
+	return tag, nil
+}
+
+// isRemainType. map[string]cadence.Value or map[string]any, including named types.
+func isRemainType(t reflect.Type) bool {
+	return t.Kind() == reflect.Map && t.Key().Kind() == reflect.String && (t.Elem() == cadenceValueType || t.Elem() == anyType)
+}
+
+// parseCadenceType. parse type in cadence source, e.g. UFix64, [UInt8] or {String: UFix64}?.
+func parseCadenceType(source string) (cadence.Type, error) {
+	typ, errs := parser2.ParseType(source, nil)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("cannot parse type %s: %w", source, errs[0])
+	}
+	return astTypeToCadence(typ, nil)
+}
+
+// structField. exported and not skipped go struct field with its godence tag.
+type structField struct {
+	index int
+	name  string
+	tag   fieldTag
+	// name in json tag, empty if not specified
+	jsonName string
+}
+
+// cadenceName. cadence field name of the go field, named by the naming strategy if the tag has no name.
+func (f structField) cadenceName(naming fieldNaming) string {
+	if f.tag.name != "" {
+		return f.tag.name
+	}
+	if naming.jsonTags && f.jsonName != "" {
+		return f.jsonName
+	}
+	return naming.strategy.fieldName(f.name)
+}
+
+// jsonNameOf. name in json tag, empty if not specified or the field is skipped by json.
+func jsonNameOf(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// structFieldsResult. cached result of structFieldsOf.
+type structFieldsResult struct {
+	fields []structField
+	err    error
+}
+
+// reflect.Type -> structFieldsResult
+var structFieldsCache sync.Map
+
+// structFieldsOf. exported and not skipped fields of go struct type, tags are parsed once per type.
+// At most one field is tagged remain.
+func structFieldsOf(t reflect.Type) ([]structField, error) {
+	if cached, ok := structFieldsCache.Load(t); ok {
+		result := cached.(structFieldsResult)
+		return result.fields, result.err
+	}
+	result := structFieldsResult{}
+	// name of remain field
+	remain := ""
+	for i := 0; i < t.NumField(); i++ {
+		fieldT := t.Field(i)
+		// cannot set, skip
+		if !fieldT.IsExported() {
+			continue
+		}
+		tag, err := parseFieldTag(fieldT)
+		if err != nil {
+			result = structFieldsResult{err: err}
+			break
+		}
+		if tag.skip {
+			continue
+		}
+		if tag.remain {
+			if remain != "" {
+				result = structFieldsResult{err: fmt.Errorf("invalid godence tag of field %s: remain field %s already exists", fieldT.Name, remain)}
+				break
+			}
+			remain = fieldT.Name
+		}
+		result.fields = append(result.fields, structField{index: i, name: fieldT.Name, tag: tag, jsonName: jsonNameOf(fieldT)})
+	}
+	structFieldsCache.Store(t, result)
+	return result.fields, result.err
+}
+
+// isEmptyValue. nil, zero number, false, and empty string, array, slice or map are empty, used by omitempty.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Pointer:
+		return v.IsNil()
+	}
+	return false
+}
+
+// checkTypeHint. check if cadence value decoded from the field has the type in tag, nil is always accepted.
+func checkTypeHint(value cadence.Value, hint cadence.Type, goType reflect.Type) error {
+	if hint == nil || isNilOptional(value) {
+		return nil
+	}
+	// optional field, check the inner value if the hint is not optional
+	if optional, ok := value.(cadence.Optional); ok {
+		if _, hintOptional := hint.(cadence.OptionalType); !hintOptional {
+			value = optional.Value
+		}
+	}
+	if !isCadenceValueCompatible(value, hint) {
+		return &TypeMismatchError{CadenceType: value.Type(), GoType: goType}
+	}
+	return nil
+}
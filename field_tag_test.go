@@ -0,0 +1,460 @@
+package godence
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+)
+
+// tagTestListing. go struct with all options of godence tag.
+type tagTestListing struct {
+	ID      uint64  `godence:"id"`
+	Price   float64 `godence:"price,type=UFix64"`
+	Seller  *string `godence:"seller,omitempty"`
+	Note    string  `godence:"note,optional"`
+	Session string  `godence:"-"`
+}
+
+func ExampleToCadence_tagOptions() {
+	type listing struct {
+		Price  float64 `godence:"price,type=UFix64"`
+		Seller *string `godence:"seller,omitempty"`
+		Cache  string  `godence:"-"`
+	}
+	_ = RegisterStruct("A.f8d6e0586b0a20c7.Market.Listing", listing{})
+	cadenceValue, err := ToCadence(listing{Price: 1.5, Cache: "LemonNeko"})
+	fmt.Printf("value: %s, err: %v", cadenceValue, err)
+	//Output: value: A.f8d6e0586b0a20c7.Market.Listing(price: 1.50000000, seller: nil), err: <nil>
+}
+
+func TestParseFieldTag(t *testing.T) {
+	field := func(tag string) reflect.StructField {
+		return reflect.StructField{Name: "Value", Tag: reflect.StructTag(tag)}
+	}
+
+	t.Run("options", func(t *testing.T) {
+		assert := assert.New(t)
+
+		tag, err := parseFieldTag(field(`godence:"amount,optional,omitempty,type=UFix64"`))
+		assert.NoError(err)
+		assert.Equal(fieldTag{name: "amount", optional: true, omitEmpty: true, typ: cadence.UFix64Type{}}, tag)
+
+		tag, err = parseFieldTag(field(`godence:"-"`))
+		assert.NoError(err)
+		assert.True(tag.skip)
+
+		tag, err = parseFieldTag(field(`godence:",optional"`))
+		assert.NoError(err)
+		assert.Equal(fieldTag{optional: true}, tag)
+
+		tag, err = parseFieldTag(field(`json:"value"`))
+		assert.NoError(err)
+		assert.Equal(fieldTag{}, tag)
+	})
+
+	t.Run("type", func(t *testing.T) {
+		assert := assert.New(t)
+
+		tag, err := parseFieldTag(field(`godence:"ids,type=[UInt64]"`))
+		assert.NoError(err)
+		assert.Equal(cadence.VariableSizedArrayType{ElementType: cadence.UInt64Type{}}, tag.typ)
+
+		tag, err = parseFieldTag(field(`godence:"balances,type={Address: UFix64}?"`))
+		assert.NoError(err)
+		assert.Equal(cadence.OptionalType{Type: cadence.DictionaryType{KeyType: cadence.AddressType{}, ElementType: cadence.UFix64Type{}}}, tag.typ)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		assert := assert.New(t)
+
+		_, err := parseFieldTag(field(`godence:"amount,required"`))
+		assert.EqualError(err, `invalid godence tag of field Value: unknown option "required"`)
+
+		_, err = parseFieldTag(field(`godence:"amount,type=[UFix64"`))
+		assert.ErrorContains(err, "invalid godence tag of field Value: cannot parse type [UFix64")
+	})
+}
+
+func TestTagOptionsToGo(t *testing.T) {
+	typ := &cadence.StructType{
+		QualifiedIdentifier: "Market.Listing",
+		Fields: []cadence.Field{
+			{Identifier: "id", Type: cadence.UInt64Type{}},
+			{Identifier: "price", Type: cadence.UFix64Type{}},
+			{Identifier: "seller", Type: cadence.OptionalType{Type: cadence.StringType{}}},
+			{Identifier: "Session", Type: cadence.StringType{}},
+		},
+	}
+
+	t.Run("script result", func(t *testing.T) {
+		assert := assert.New(t)
+		script := []byte(`
+		pub struct Listing {
+			pub let id: UInt64
+			pub let price: UFix64
+			pub let seller: String?
+			init() {
+				self.id = 15
+				self.price = 1.5
+				self.seller = nil
+			}
+		}
+		pub fun main(): Listing { return Listing() }`)
+
+		ret, err := flowCli.ExecuteScriptAtLatestBlock(context.Background(), script, nil)
+		assert.NoError(err)
+
+		dist := tagTestListing{Note: "keep"}
+		assert.NoError(ToGo(ret, &dist))
+		assert.Equal(tagTestListing{ID: 15, Price: 1.5, Note: "keep"}, dist)
+	})
+
+	t.Run("skip and optional", func(t *testing.T) {
+		assert := assert.New(t)
+		value := cadence.NewStruct([]cadence.Value{
+			cadence.NewUInt64(15),
+			cadence.UFix64(150000000),
+			cadence.NewOptional(cadence.String("LemonNeko")),
+			cadence.String("session"),
+		}).WithType(typ)
+
+		dist := tagTestListing{Note: "keep", Session: "keep"}
+		assert.NoError(ToGo(value, &dist))
+		assert.Equal(uint64(15), dist.ID)
+		assert.Equal("LemonNeko", *dist.Seller)
+		// not in cadence struct
+		assert.Equal("keep", dist.Note)
+		// skipped
+		assert.Equal("keep", dist.Session)
+	})
+
+	t.Run("type mismatch", func(t *testing.T) {
+		type listing struct {
+			Price float64 `godence:"price,type=UFix64"`
+		}
+		assert := assert.New(t)
+		value := cadence.NewStruct([]cadence.Value{cadence.Fix64(150000000)}).WithType(&cadence.StructType{
+			QualifiedIdentifier: "Listing",
+			Fields:              []cadence.Field{{Identifier: "price", Type: cadence.Fix64Type{}}},
+		})
+
+		dist := listing{}
+		err := ToGo(value, &dist)
+		assert.ErrorIs(err, ErrTypeMismatch)
+		assert.EqualError(err, "Listing.price: cannot convert Fix64 to float64")
+
+		// optional values are checked by the inner type
+		value = cadence.NewStruct([]cadence.Value{cadence.NewOptional(cadence.UFix64(150000000))}).WithType(&cadence.StructType{
+			QualifiedIdentifier: "Listing",
+			Fields:              []cadence.Field{{Identifier: "price", Type: cadence.OptionalType{Type: cadence.UFix64Type{}}}},
+		})
+		assert.NoError(ToGo(value, &dist))
+		assert.Equal(1.5, dist.Price)
+	})
+
+	t.Run("invalid tag", func(t *testing.T) {
+		type listing struct {
+			Price float64 `godence:"price,required"`
+		}
+		assert := assert.New(t)
+		value := cadence.NewStruct([]cadence.Value{cadence.UFix64(150000000)}).WithType(&cadence.StructType{
+			QualifiedIdentifier: "Listing",
+			Fields:              []cadence.Field{{Identifier: "price", Type: cadence.UFix64Type{}}},
+		})
+
+		dist := listing{}
+		assert.EqualError(ToGo(value, &dist), `invalid godence tag of field Price: unknown option "required"`)
+	})
+}
+
+func TestTagOptionsToCadence(t *testing.T) {
+	assert.NoError(t, RegisterStruct("A.f8d6e0586b0a20c7.Market.Listing", tagTestListing{}))
+	typ := &cadence.StructType{
+		QualifiedIdentifier: "Market.Listing",
+		Fields: []cadence.Field{
+			{Identifier: "id", Type: cadence.UInt64Type{}},
+			{Identifier: "price", Type: cadence.UFix64Type{}},
+			{Identifier: "seller", Type: cadence.OptionalType{Type: cadence.StringType{}}},
+		},
+	}
+
+	t.Run("ToCadence", func(t *testing.T) {
+		assert := assert.New(t)
+		seller := "LemonNeko"
+
+		cadenceValue, err := ToCadence(tagTestListing{ID: 15, Price: 1.5, Seller: &seller, Session: "session"})
+		assert.NoError(err)
+		s := cadenceValue.(cadence.Struct)
+		assert.Equal([]cadence.Field{
+			{Identifier: "id", Type: cadence.UInt64Type{}},
+			{Identifier: "price", Type: cadence.UFix64Type{}},
+			{Identifier: "seller", Type: cadence.OptionalType{Type: cadence.StringType{}}},
+			{Identifier: "note", Type: cadence.StringType{}},
+		}, s.StructType.Fields)
+		assert.Equal([]cadence.Value{
+			cadence.NewUInt64(15),
+			cadence.UFix64(150000000),
+			cadence.NewOptional(cadence.String("LemonNeko")),
+			cadence.String(""),
+		}, s.Fields)
+
+		cadenceValue, err = ToCadence(tagTestListing{ID: 15})
+		assert.NoError(err)
+		assert.Equal(cadence.NewOptional(nil), cadenceValue.(cadence.Struct).Fields[2])
+	})
+
+	t.Run("ToCadenceAs", func(t *testing.T) {
+		assert := assert.New(t)
+
+		cadenceValue, err := ToCadenceAs(tagTestListing{ID: 15, Price: 1.5}, typ)
+		assert.NoError(err)
+		assert.Equal(cadence.NewStruct([]cadence.Value{
+			cadence.NewUInt64(15),
+			cadence.UFix64(150000000),
+			cadence.NewOptional(nil),
+		}).WithType(typ), cadenceValue)
+
+		// optional and skipped fields are not in cadence struct
+		_, err = NewEncoder(WithStrict()).EncodeAs(tagTestListing{ID: 15, Price: 1.5}, typ)
+		assert.NoError(err)
+	})
+
+	t.Run("omitempty requires optional", func(t *testing.T) {
+		type listing struct {
+			Seller string `godence:"seller,omitempty"`
+		}
+		assert := assert.New(t)
+		typ := &cadence.StructType{
+			QualifiedIdentifier: "Listing",
+			Fields:              []cadence.Field{{Identifier: "seller", Type: cadence.StringType{}}},
+		}
+
+		_, err := ToCadenceAs(listing{}, typ)
+		assert.ErrorIs(err, ErrTypeMismatch)
+		assert.EqualError(err, "seller: cannot convert string to String")
+
+		cadenceValue, err := ToCadenceAs(listing{Seller: "LemonNeko"}, typ)
+		assert.NoError(err)
+		assert.Equal(cadence.NewStruct([]cadence.Value{cadence.String("LemonNeko")}).WithType(typ), cadenceValue)
+	})
+
+	t.Run("type mismatch", func(t *testing.T) {
+		type listing struct {
+			Price float64 `godence:"price,type=UFix64"`
+		}
+		assert := assert.New(t)
+		typ := &cadence.StructType{
+			QualifiedIdentifier: "Listing",
+			Fields:              []cadence.Field{{Identifier: "price", Type: cadence.Fix64Type{}}},
+		}
+
+		_, err := ToCadenceAs(listing{Price: 1.5}, typ)
+		assert.EqualError(err, "price: cannot convert cadence.UFix64 to Fix64")
+
+		_, err = ToCadenceAs(listing{Price: -1.5}, &cadence.StructType{
+			QualifiedIdentifier: "Listing",
+			Fields:              []cadence.Field{{Identifier: "price", Type: cadence.UFix64Type{}}},
+		})
+		assert.ErrorIs(err, ErrOverflow)
+	})
+}
+
+func TestRemainField(t *testing.T) {
+	// only some fields of manyTypeEvent, others are collected
+	type event struct {
+		IntValue    int64                    `godence:"intValue"`
+		StringValue string                   `godence:"stringValue"`
+		Remain      map[string]cadence.Value `godence:",remain"`
+	}
+	type eventAny struct {
+		IntValue int64          `godence:"intValue"`
+		Remain   map[string]any `godence:",remain"`
+	}
+	address := cadence.NewAddress([8]uint8{0xf8, 0xd6, 0xe0, 0x58, 0x6b, 0x0a, 0x20, 0xc7})
+
+	t.Run("script result", func(t *testing.T) {
+		assert := assert.New(t)
+		script := []byte(`
+		pub struct User {
+			pub let myName: String
+			pub let age: UInt8
+			pub let tags: [String]
+			init() {
+				self.myName = "LemonNeko"
+				self.age = 18
+				self.tags = ["cat"]
+			}
+		}
+		pub fun main(): User { return User() }`)
+		type user struct {
+			MyName string                   `godence:"myName"`
+			Remain map[string]cadence.Value `godence:",remain"`
+		}
+
+		ret, err := flowCli.ExecuteScriptAtLatestBlock(context.Background(), script, nil)
+		assert.NoError(err)
+
+		dist := user{}
+		assert.NoError(NewDecoder(WithStrict()).Decode(ret, &dist))
+		assert.Equal("LemonNeko", dist.MyName)
+		assert.Equal(map[string]cadence.Value{
+			"age":  cadence.NewUInt8(18),
+			"tags": cadence.NewArray([]cadence.Value{cadence.String("cat")}).WithType(cadence.VariableSizedArrayType{ElementType: cadence.StringType{}}),
+		}, dist.Remain)
+	})
+
+	t.Run("cadence values", func(t *testing.T) {
+		assert := assert.New(t)
+
+		dist := event{}
+		assert.NoError(ToGo(manyTypeEvent(), &dist))
+		assert.Equal(event{
+			IntValue:    -15,
+			StringValue: "LemonNeko",
+			Remain: map[string]cadence.Value{
+				"boolValue": cadence.NewBool(true),
+				"uintValue": cadence.NewUInt64(15),
+				"address":   address,
+			},
+		}, dist)
+	})
+
+	t.Run("go values", func(t *testing.T) {
+		assert := assert.New(t)
+
+		dist := eventAny{Remain: map[string]any{"kept": true}}
+		assert.NoError(ToGo(manyTypeEvent(), &dist))
+		assert.Equal(map[string]any{
+			"kept":        true,
+			"boolValue":   true,
+			"uintValue":   uint64(15),
+			"stringValue": "LemonNeko",
+			"address":     address.ToGoValue(),
+		}, dist.Remain)
+	})
+
+	t.Run("strict and handler", func(t *testing.T) {
+		assert := assert.New(t)
+		decoder := NewDecoder(WithStrict(), WithUnmappedFieldHandler(func(typ cadence.Type, field string, value cadence.Value) error {
+			return fmt.Errorf("unknown field %s", field)
+		}))
+
+		// all fields are consumed by the remain field
+		dist := event{}
+		assert.NoError(decoder.Decode(manyTypeEvent(), &dist))
+		assert.Len(dist.Remain, 3)
+	})
+
+	t.Run("encode", func(t *testing.T) {
+		assert := assert.New(t)
+		assert.NoError(RegisterStruct("A.f8d6e0586b0a20c7.PlanTest.ManyType", event{}))
+		value := event{
+			IntValue:    -15,
+			StringValue: "LemonNeko",
+			Remain: map[string]cadence.Value{
+				"uintValue": cadence.NewUInt64(15),
+				"boolValue": cadence.NewBool(true),
+				// the go field takes precedence
+				"intValue": cadence.NewInt64(0),
+			},
+		}
+
+		cadenceValue, err := ToCadence(value)
+		assert.NoError(err)
+		s := cadenceValue.(cadence.Struct)
+		assert.Equal([]cadence.Field{
+			{Identifier: "intValue", Type: cadence.Int64Type{}},
+			{Identifier: "stringValue", Type: cadence.StringType{}},
+			{Identifier: "boolValue", Type: cadence.BoolType{}},
+			{Identifier: "uintValue", Type: cadence.UInt64Type{}},
+		}, s.StructType.Fields)
+		assert.Equal([]cadence.Value{cadence.NewInt64(-15), cadence.String("LemonNeko"), cadence.NewBool(true), cadence.NewUInt64(15)}, s.Fields)
+	})
+
+	t.Run("encode as", func(t *testing.T) {
+		assert := assert.New(t)
+		expect := manyTypeEvent()
+		typ := &cadence.StructType{QualifiedIdentifier: expect.EventType.QualifiedIdentifier, Fields: expect.EventType.Fields}
+
+		// round trip
+		dist := event{}
+		assert.NoError(ToGo(expect, &dist))
+		cadenceValue, err := NewEncoder(WithStrict()).EncodeAs(dist, typ)
+		assert.NoError(err)
+		assert.Equal(expect.Fields, cadenceValue.(cadence.Struct).Fields)
+
+		// go values are converted to the field type
+		cadenceValue, err = ToCadenceAs(eventAny{IntValue: -15, Remain: map[string]any{
+			"boolValue":   true,
+			"uintValue":   15,
+			"stringValue": "LemonNeko",
+			"address":     "0xf8d6e0586b0a20c7",
+		}}, typ)
+		assert.NoError(err)
+		assert.Equal(expect.Fields, cadenceValue.(cadence.Struct).Fields)
+
+		_, err = ToCadenceAs(eventAny{Remain: map[string]any{"uintValue": -15}}, typ)
+		assert.ErrorIs(err, ErrFieldNotFound)
+
+		dist.Remain["extra"] = cadence.String("LemonNeko")
+		_, err = NewEncoder(WithStrict()).EncodeAs(dist, typ)
+		assert.EqualError(err, "cannot find field named extra in PlanTest.ManyType")
+
+		// nil is collected and converted back
+		nilType := &cadence.StructType{
+			QualifiedIdentifier: "PlanTest.Nil",
+			Fields: []cadence.Field{
+				{Identifier: "a", Type: cadence.IntType{}},
+				{Identifier: "b", Type: cadence.OptionalType{Type: cadence.IntType{}}},
+			},
+		}
+		nilValue := cadence.NewStruct([]cadence.Value{cadence.NewInt(1), cadence.NewOptional(nil)}).WithType(nilType)
+		type rest struct {
+			A    int                      `godence:"a"`
+			Rest map[string]cadence.Value `godence:",remain"`
+		}
+		type restAny struct {
+			A    int            `godence:"a"`
+			Rest map[string]any `godence:",remain"`
+		}
+
+		restDist := rest{}
+		assert.NoError(ToGo(nilValue, &restDist))
+		assert.Equal(map[string]cadence.Value{"b": cadence.NewOptional(nil)}, restDist.Rest)
+		cadenceValue, err = ToCadenceAs(restDist, nilType)
+		assert.NoError(err)
+		assert.Equal(nilValue, cadenceValue)
+
+		restAnyDist := restAny{}
+		assert.NoError(ToGo(nilValue, &restAnyDist))
+		assert.Equal(map[string]any{"b": nil}, restAnyDist.Rest)
+		cadenceValue, err = ToCadenceAs(restAnyDist, nilType)
+		assert.NoError(err)
+		assert.Equal(nilValue, cadenceValue)
+
+		// without cadence type
+		assert.NoError(RegisterStruct("PlanTest.Nil", restAny{}))
+		cadenceValue, err = ToCadence(restAnyDist)
+		assert.NoError(err)
+		assert.Equal([]cadence.Value{cadence.NewInt(1), cadence.NewOptional(nil)}, cadenceValue.(cadence.Struct).Fields)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		assert := assert.New(t)
+
+		_, err := structFieldsOf(reflect.TypeOf(struct {
+			Remain map[string]string `godence:",remain"`
+		}{}))
+		assert.EqualError(err, "invalid godence tag of field Remain: remain field should be map[string]cadence.Value or map[string]any")
+
+		_, err = structFieldsOf(reflect.TypeOf(struct {
+			Remain  map[string]any           `godence:",remain"`
+			Remain2 map[string]cadence.Value `godence:",remain"`
+		}{}))
+		assert.EqualError(err, "invalid godence tag of field Remain2: remain field Remain already exists")
+	})
+}
@@ -0,0 +1,245 @@
+package godence
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/onflow/cadence"
+)
+
+// scale factor of Fix64 and UFix64
+const fix64Factor = 100000000
+
+// helper for ufix64.
+// The value is scaled by 1e8, the same as cadence.UFix64, e.g. UFix64(150000000) is 1.5.
+// Use ParseUFix64 to get it from decimal string.
+type UFix64 uint64
+
+// helper for fix64.
+// The value is scaled by 1e8, the same as cadence.Fix64, e.g. Fix64(-150000000) is -1.5.
+// Use ParseFix64 to get it from decimal string.
+type Fix64 int64
+
+// withDecimalPoint. cadence requires decimal point, whole number like "5" should be "5.0".
+func withDecimalPoint(s string) string {
+	if strings.Contains(s, ".") {
+		return s
+	}
+	return s + ".0"
+}
+
+// ParseUFix64 Parse decimal string like "12.345" or "12" to UFix64, at most 8 fractional digits.
+func ParseUFix64(s string) (UFix64, error) {
+	v, err := cadence.NewUFix64(withDecimalPoint(s))
+	if err != nil {
+		return 0, err
+	}
+	return UFix64(v), nil
+}
+
+// ParseFix64 Parse decimal string like "-12.345" or "-12" to Fix64, at most 8 fractional digits.
+func ParseFix64(s string) (Fix64, error) {
+	v, err := cadence.NewFix64(withDecimalPoint(s))
+	if err != nil {
+		return 0, err
+	}
+	return Fix64(v), nil
+}
+
+// String Format UFix64 to decimal string with 8 fractional digits, e.g. "12.34500000".
+func (v UFix64) String() string {
+	return cadence.UFix64(v).String()
+}
+
+// String Format Fix64 to decimal string with 8 fractional digits, e.g. "-12.34500000".
+func (v Fix64) String() string {
+	return cadence.Fix64(v).String()
+}
+
+// fix64FactorBig. fix64Factor in big.Int
+var fix64FactorBig = big.NewInt(fix64Factor)
+
+// ErrDivisionByZero Returned by Div of UFix64 and Fix64 if the divisor is zero.
+var ErrDivisionByZero = errors.New("division by zero")
+
+// checkUFix64. check if result of operation is in range of UFix64, the error is OverflowError.
+func checkUFix64(result *big.Int) (UFix64, error) {
+	if !result.IsUint64() {
+		return 0, &OverflowError{Value: formatFixedPoint(result), CadenceType: cadence.UFix64Type{}, GoType: reflect.TypeOf(UFix64(0)), toCadence: true}
+	}
+	return UFix64(result.Uint64()), nil
+}
+
+// Add Return v + o, OverflowError if overflow.
+func (v UFix64) Add(o UFix64) (UFix64, error) {
+	result := new(big.Int).Add(new(big.Int).SetUint64(uint64(v)), new(big.Int).SetUint64(uint64(o)))
+	return checkUFix64(result)
+}
+
+// Sub Return v - o, OverflowError if underflow.
+func (v UFix64) Sub(o UFix64) (UFix64, error) {
+	result := new(big.Int).Sub(new(big.Int).SetUint64(uint64(v)), new(big.Int).SetUint64(uint64(o)))
+	return checkUFix64(result)
+}
+
+// Mul Return v * o, fractional digits beyond 8 are truncated, OverflowError if overflow.
+func (v UFix64) Mul(o UFix64) (UFix64, error) {
+	result := new(big.Int).Mul(new(big.Int).SetUint64(uint64(v)), new(big.Int).SetUint64(uint64(o)))
+	result.Div(result, fix64FactorBig)
+	return checkUFix64(result)
+}
+
+// Div Return v / o, fractional digits beyond 8 are truncated, OverflowError if overflow, ErrDivisionByZero if o is zero.
+func (v UFix64) Div(o UFix64) (UFix64, error) {
+	if o == 0 {
+		return 0, fmt.Errorf("UFix64 %w: %s / %s", ErrDivisionByZero, v, o)
+	}
+	result := new(big.Int).Mul(new(big.Int).SetUint64(uint64(v)), fix64FactorBig)
+	result.Div(result, new(big.Int).SetUint64(uint64(o)))
+	return checkUFix64(result)
+}
+
+// checkFix64. check if result of operation is in range of Fix64, the error is OverflowError.
+func checkFix64(result *big.Int) (Fix64, error) {
+	if !result.IsInt64() {
+		return 0, &OverflowError{Value: formatFixedPoint(result), CadenceType: cadence.Fix64Type{}, GoType: reflect.TypeOf(Fix64(0)), toCadence: true}
+	}
+	return Fix64(result.Int64()), nil
+}
+
+// Add Return v + o, OverflowError if overflow or underflow.
+func (v Fix64) Add(o Fix64) (Fix64, error) {
+	result := new(big.Int).Add(big.NewInt(int64(v)), big.NewInt(int64(o)))
+	return checkFix64(result)
+}
+
+// Sub Return v - o, OverflowError if overflow or underflow.
+func (v Fix64) Sub(o Fix64) (Fix64, error) {
+	result := new(big.Int).Sub(big.NewInt(int64(v)), big.NewInt(int64(o)))
+	return checkFix64(result)
+}
+
+// Mul Return v * o, rounded the same as cadence, OverflowError if overflow or underflow.
+func (v Fix64) Mul(o Fix64) (Fix64, error) {
+	result := new(big.Int).Mul(big.NewInt(int64(v)), big.NewInt(int64(o)))
+	result.Div(result, fix64FactorBig)
+	return checkFix64(result)
+}
+
+// Div Return v / o, rounded the same as cadence, OverflowError if overflow or underflow, ErrDivisionByZero if o is zero.
+func (v Fix64) Div(o Fix64) (Fix64, error) {
+	if o == 0 {
+		return 0, fmt.Errorf("Fix64 %w: %s / %s", ErrDivisionByZero, v, o)
+	}
+	result := new(big.Int).Mul(big.NewInt(int64(v)), fix64FactorBig)
+	result.Div(result, big.NewInt(int64(o)))
+	return checkFix64(result)
+}
+
+// RoundingMode How to round a float that has more than 8 fractional digits.
+type RoundingMode int
+
+const (
+	// RoundHalfEven Round to nearest, ties to even. Used by ToCadence.
+	RoundHalfEven RoundingMode = iota
+	// RoundTruncate Drop the extra fractional digits, round toward zero.
+	RoundTruncate
+	// RoundExact Reject float that cannot be represented exactly.
+	RoundExact
+)
+
+// floatToFixedPoint. convert float to value scaled by 1e8 with rounding mode.
+// Float is treated as its shortest decimal representation, so 0.1 is exactly 0.1.
+// NaN and Inf are OverflowError, float rejected by RoundExact is TypeMismatchError.
+func floatToFixedPoint(f float64, bitSize int, mode RoundingMode, typ cadence.Type) (*big.Int, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, &OverflowError{Value: strconv.FormatFloat(f, 'f', -1, bitSize), CadenceType: typ, GoType: floatType(bitSize), toCadence: true}
+	}
+	s := strconv.FormatFloat(f, 'f', -1, bitSize)
+	negative := strings.HasPrefix(s, "-")
+	whole, fraction, _ := strings.Cut(strings.TrimPrefix(s, "-"), ".")
+	raw, _ := new(big.Int).SetString(whole+fraction, 10)
+	if len(fraction) <= 8 {
+		raw.Mul(raw, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(8-len(fraction))), nil))
+	} else {
+		divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(len(fraction)-8)), nil)
+		remainder := new(big.Int)
+		raw.QuoRem(raw, divisor, remainder)
+		if remainder.Sign() != 0 {
+			switch mode {
+			case RoundExact:
+				return nil, fmt.Errorf("value %s cannot be represented exactly: %w", s, &TypeMismatchError{CadenceType: typ, GoType: floatType(bitSize), toCadence: true})
+			case RoundHalfEven:
+				cmp := remainder.Lsh(remainder, 1).Cmp(divisor)
+				if cmp > 0 || (cmp == 0 && raw.Bit(0) == 1) {
+					raw.Add(raw, big.NewInt(1))
+				}
+			}
+		}
+	}
+	if negative {
+		raw.Neg(raw)
+	}
+	return raw, nil
+}
+
+// floatType. go type of float with bit size.
+func floatType(bitSize int) reflect.Type {
+	if bitSize == 32 {
+		return reflect.TypeOf(float32(0))
+	}
+	return reflect.TypeOf(float64(0))
+}
+
+// ufix64FromFloat. bitSize is 32 for float32, 64 for float64.
+func ufix64FromFloat(f float64, bitSize int, mode RoundingMode) (UFix64, error) {
+	raw, err := floatToFixedPoint(f, bitSize, mode, cadence.UFix64Type{})
+	if err != nil {
+		return 0, err
+	}
+	if !raw.IsUint64() {
+		return 0, &OverflowError{Value: strconv.FormatFloat(f, 'f', -1, bitSize), CadenceType: cadence.UFix64Type{}, GoType: floatType(bitSize), toCadence: true}
+	}
+	return UFix64(raw.Uint64()), nil
+}
+
+// fix64FromFloat. bitSize is 32 for float32, 64 for float64.
+func fix64FromFloat(f float64, bitSize int, mode RoundingMode) (Fix64, error) {
+	raw, err := floatToFixedPoint(f, bitSize, mode, cadence.Fix64Type{})
+	if err != nil {
+		return 0, err
+	}
+	if !raw.IsInt64() {
+		return 0, &OverflowError{Value: strconv.FormatFloat(f, 'f', -1, bitSize), CadenceType: cadence.Fix64Type{}, GoType: floatType(bitSize), toCadence: true}
+	}
+	return Fix64(raw.Int64()), nil
+}
+
+// UFix64FromFloat Convert float to UFix64, mode decides how to handle fractional digits beyond 8.
+// Error if f is negative, too large, NaN or Inf.
+func UFix64FromFloat(f float64, mode RoundingMode) (UFix64, error) {
+	return ufix64FromFloat(f, 64, mode)
+}
+
+// Fix64FromFloat Convert float to Fix64, mode decides how to handle fractional digits beyond 8.
+// Error if f is out of range, NaN or Inf.
+func Fix64FromFloat(f float64, mode RoundingMode) (Fix64, error) {
+	return fix64FromFloat(f, 64, mode)
+}
+
+// Float64 Convert UFix64 to the nearest float64.
+func (v UFix64) Float64() float64 {
+	f, _ := strconv.ParseFloat(v.String(), 64)
+	return f
+}
+
+// Float64 Convert Fix64 to the nearest float64.
+func (v Fix64) Float64() float64 {
+	f, _ := strconv.ParseFloat(v.String(), 64)
+	return f
+}
@@ -0,0 +1,288 @@
+package godence
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleParseUFix64() {
+	amount, err := ParseUFix64("0.5")
+	fmt.Printf("raw: %d, string: %s, err: %v", uint64(amount), amount, err)
+	//Output: raw: 50000000, string: 0.50000000, err: <nil>
+}
+
+func TestParseUFix64(t *testing.T) {
+	t.Run("fractional", func(t *testing.T) {
+		assert := assert.New(t)
+		v, err := ParseUFix64("12.345")
+		assert.NoError(err)
+		assert.Equal(UFix64(1234500000), v)
+		assert.Equal("12.34500000", v.String())
+	})
+	t.Run("whole number", func(t *testing.T) {
+		assert := assert.New(t)
+		v, err := ParseUFix64("5")
+		assert.NoError(err)
+		assert.Equal(UFix64(500000000), v)
+	})
+	t.Run("too many fractional digits", func(t *testing.T) {
+		assert := assert.New(t)
+		_, err := ParseUFix64("0.123456789")
+		assert.Error(err)
+	})
+	t.Run("negative", func(t *testing.T) {
+		assert := assert.New(t)
+		_, err := ParseUFix64("-1.0")
+		assert.Error(err)
+	})
+	t.Run("not a number", func(t *testing.T) {
+		assert := assert.New(t)
+		_, err := ParseUFix64("LemonNeko")
+		assert.Error(err)
+	})
+}
+
+func TestParseFix64(t *testing.T) {
+	t.Run("negative", func(t *testing.T) {
+		assert := assert.New(t)
+		v, err := ParseFix64("-12.345")
+		assert.NoError(err)
+		assert.Equal(Fix64(-1234500000), v)
+		assert.Equal("-12.34500000", v.String())
+	})
+	t.Run("out of range", func(t *testing.T) {
+		assert := assert.New(t)
+		_, err := ParseFix64("92233720368.54775808")
+		assert.Error(err)
+	})
+}
+
+func TestUFix64Arithmetic(t *testing.T) {
+	tests := []struct {
+		name    string
+		op      func(UFix64, UFix64) (UFix64, error)
+		a       UFix64
+		b       UFix64
+		want    UFix64
+		wantErr string
+		wantIs  error
+	}{
+		{name: "add", op: UFix64.Add, a: 150000000, b: 50000000, want: 200000000},
+		{name: "add overflow", op: UFix64.Add, a: math.MaxUint64, b: 1, wantErr: "value 184467440737.09551616 out of range of UFix64", wantIs: ErrOverflow},
+		{name: "sub", op: UFix64.Sub, a: 150000000, b: 50000000, want: 100000000},
+		{name: "sub underflow", op: UFix64.Sub, a: 0, b: 1, wantErr: "value -0.00000001 out of range of UFix64", wantIs: ErrOverflow},
+		{name: "mul", op: UFix64.Mul, a: 150000000, b: 50000000, want: 75000000},
+		{name: "mul truncated", op: UFix64.Mul, a: 1, b: 50000000, want: 0},
+		{name: "mul overflow", op: UFix64.Mul, a: math.MaxUint64, b: 200000000, wantErr: "value 368934881474.19103230 out of range of UFix64", wantIs: ErrOverflow},
+		{name: "div", op: UFix64.Div, a: 100000000, b: 300000000, want: 33333333},
+		{name: "div overflow", op: UFix64.Div, a: math.MaxUint64, b: 50000000, wantErr: "value 368934881474.19103230 out of range of UFix64", wantIs: ErrOverflow},
+		{name: "div by zero", op: UFix64.Div, a: 100000000, b: 0, wantErr: "UFix64 division by zero: 1.00000000 / 0.00000000", wantIs: ErrDivisionByZero},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			got, err := tt.op(tt.a, tt.b)
+			if tt.wantErr != "" {
+				assert.EqualError(err, tt.wantErr)
+				assert.ErrorIs(err, tt.wantIs)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestFix64Arithmetic(t *testing.T) {
+	tests := []struct {
+		name    string
+		op      func(Fix64, Fix64) (Fix64, error)
+		a       Fix64
+		b       Fix64
+		want    Fix64
+		wantErr string
+		wantIs  error
+	}{
+		{name: "add", op: Fix64.Add, a: -150000000, b: 50000000, want: -100000000},
+		{name: "add overflow", op: Fix64.Add, a: math.MaxInt64, b: 1, wantErr: "value 92233720368.54775808 out of range of Fix64", wantIs: ErrOverflow},
+		{name: "sub", op: Fix64.Sub, a: 50000000, b: 150000000, want: -100000000},
+		{name: "sub underflow", op: Fix64.Sub, a: math.MinInt64, b: 1, wantErr: "value -92233720368.54775809 out of range of Fix64", wantIs: ErrOverflow},
+		{name: "mul", op: Fix64.Mul, a: -150000000, b: 50000000, want: -75000000},
+		{name: "mul overflow", op: Fix64.Mul, a: math.MaxInt64, b: 200000000, wantErr: "value 184467440737.09551614 out of range of Fix64", wantIs: ErrOverflow},
+		{name: "mul underflow", op: Fix64.Mul, a: math.MaxInt64, b: -200000000, wantErr: "value -184467440737.09551614 out of range of Fix64", wantIs: ErrOverflow},
+		{name: "div", op: Fix64.Div, a: 100000000, b: 300000000, want: 33333333},
+		{name: "div by zero", op: Fix64.Div, a: 100000000, b: 0, wantErr: "Fix64 division by zero: 1.00000000 / 0.00000000", wantIs: ErrDivisionByZero},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			got, err := tt.op(tt.a, tt.b)
+			if tt.wantErr != "" {
+				assert.EqualError(err, tt.wantErr)
+				assert.ErrorIs(err, tt.wantIs)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestFixedPointConversion(t *testing.T) {
+	t.Run("UFix64 to cadence", func(t *testing.T) {
+		assert := assert.New(t)
+		script := []byte(`pub fun main(arg: UFix64): UFix64 { return arg * 2.0 }`)
+		amount, err := ParseUFix64("0.5")
+		assert.NoError(err)
+
+		cadenceValue, err := ToCadence(amount)
+		assert.NoError(err)
+		assert.Equal(cadence.UFix64(50000000), cadenceValue)
+
+		args := []cadence.Value{cadenceValue}
+		ret, err := flowCli.ExecuteScriptAtLatestBlock(context.Background(), script, args)
+		assert.NoError(err)
+
+		var dist UFix64
+		err = ToGo(ret, &dist)
+		assert.NoError(err)
+		assert.Equal("1.00000000", dist.String())
+	})
+	t.Run("Fix64 to cadence", func(t *testing.T) {
+		assert := assert.New(t)
+		amount, err := ParseFix64("-0.5")
+		assert.NoError(err)
+
+		cadenceValue, err := ToCadence(amount)
+		assert.NoError(err)
+		assert.Equal(cadence.Fix64(-50000000), cadenceValue)
+	})
+	t.Run("cadence to Fix64", func(t *testing.T) {
+		assert := assert.New(t)
+
+		var dist Fix64
+		err := ToGo(cadence.Fix64(-50000000), &dist)
+		assert.NoError(err)
+		assert.Equal("-0.50000000", dist.String())
+	})
+}
+
+func TestFixedPointFromFloat(t *testing.T) {
+	tests := []struct {
+		name    string
+		f       float64
+		mode    RoundingMode
+		want    Fix64
+		wantErr string
+		wantIs  error
+	}{
+		{name: "exact", f: 0.1, mode: RoundExact, want: 10000000},
+		{name: "negative exact", f: -12.345, mode: RoundExact, want: -1234500000},
+		{name: "inexact", f: 0.123456789, mode: RoundExact, wantErr: "value 0.123456789 cannot be represented exactly: cannot convert float64 to Fix64", wantIs: ErrTypeMismatch},
+		{name: "truncate", f: 0.123456789, mode: RoundTruncate, want: 12345678},
+		{name: "negative truncate", f: -0.123456789, mode: RoundTruncate, want: -12345678},
+		{name: "half even, up", f: 0.123456789, mode: RoundHalfEven, want: 12345679},
+		{name: "half even, tie to even", f: 0.000000125, mode: RoundHalfEven, want: 12},
+		{name: "half even, tie to even up", f: 0.000000135, mode: RoundHalfEven, want: 14},
+		{name: "negative half even", f: -0.123456789, mode: RoundHalfEven, want: -12345679},
+		{name: "out of range", f: 1e11, mode: RoundHalfEven, wantErr: "value 100000000000 out of range of Fix64", wantIs: ErrOverflow},
+		{name: "NaN", f: math.NaN(), mode: RoundHalfEven, wantErr: "value NaN out of range of Fix64", wantIs: ErrOverflow},
+		{name: "Inf", f: math.Inf(1), mode: RoundHalfEven, wantErr: "value +Inf out of range of Fix64", wantIs: ErrOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			got, err := Fix64FromFloat(tt.f, tt.mode)
+			if tt.wantErr != "" {
+				assert.EqualError(err, tt.wantErr)
+				assert.ErrorIs(err, tt.wantIs)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+
+	t.Run("UFix64", func(t *testing.T) {
+		assert := assert.New(t)
+		got, err := UFix64FromFloat(1.5, RoundExact)
+		assert.NoError(err)
+		assert.Equal(UFix64(150000000), got)
+
+		_, err = UFix64FromFloat(-1.5, RoundExact)
+		assert.EqualError(err, "value -1.5 out of range of UFix64")
+
+		_, err = UFix64FromFloat(2e11, RoundExact)
+		assert.EqualError(err, "value 200000000000 out of range of UFix64")
+	})
+	t.Run("Float64", func(t *testing.T) {
+		assert := assert.New(t)
+		assert.Equal(0.1, UFix64(10000000).Float64())
+		assert.Equal(-12.345, Fix64(-1234500000).Float64())
+	})
+}
+
+func TestFloatConversion(t *testing.T) {
+	t.Run("float64 to Fix64", func(t *testing.T) {
+		assert := assert.New(t)
+		script := []byte(`pub fun main(arg: Fix64): Fix64 { return arg }`)
+
+		cadenceValue, err := ToCadence(-0.5)
+		assert.NoError(err)
+		assert.Equal(cadence.Fix64(-50000000), cadenceValue)
+
+		args := []cadence.Value{cadenceValue}
+		ret, err := flowCli.ExecuteScriptAtLatestBlock(context.Background(), script, args)
+		assert.NoError(err)
+
+		var dist float64
+		assert.NoError(ToGo(ret, &dist))
+		assert.Equal(-0.5, dist)
+	})
+	t.Run("float32 to Fix64", func(t *testing.T) {
+		assert := assert.New(t)
+		cadenceValue, err := ToCadence(float32(0.1))
+		assert.NoError(err)
+		assert.Equal(cadence.Fix64(10000000), cadenceValue)
+	})
+	t.Run("float out of range", func(t *testing.T) {
+		assert := assert.New(t)
+		cadenceValue, err := ToCadence(1e20)
+		assert.EqualError(err, "value 100000000000000000000 out of range of Fix64")
+		assert.Nil(cadenceValue)
+	})
+	t.Run("float to UFix64", func(t *testing.T) {
+		assert := assert.New(t)
+		cadenceValue, err := ToCadenceAs(0.123456789, cadence.UFix64Type{})
+		assert.NoError(err)
+		assert.Equal(cadence.UFix64(12345679), cadenceValue)
+
+		_, err = ToCadenceAs(-0.5, cadence.UFix64Type{})
+		assert.EqualError(err, "value -0.50000000 out of range of UFix64")
+	})
+	t.Run("UFix64 to float32", func(t *testing.T) {
+		assert := assert.New(t)
+		var dist float32
+		assert.NoError(ToGo(cadence.UFix64(10000000), &dist))
+		assert.Equal(float32(0.1), dist)
+	})
+	t.Run("struct fields", func(t *testing.T) {
+		type balance struct {
+			Balance float64 `godence:"balance"`
+		}
+		assert := assert.New(t)
+		value := cadence.NewStruct([]cadence.Value{cadence.UFix64(1234500000)}).WithType(&cadence.StructType{
+			QualifiedIdentifier: "Balance",
+			Fields:              []cadence.Field{{Identifier: "balance", Type: cadence.UFix64Type{}}},
+		})
+
+		dist := balance{}
+		assert.NoError(ToGo(value, &dist))
+		assert.Equal(12.345, dist.Balance)
+	})
+}
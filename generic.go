@@ -0,0 +1,38 @@
+package godence
+
+import "github.com/onflow/cadence"
+
+// Decode Convert cadence value to go value of type T, the same as ToGo.
+// e.g. name, err := Decode[string](ret). Return zero value of T if error.
+func Decode[T any](value cadence.Value) (T, error) {
+	var dist T
+	if err := ToGo(value, &dist); err != nil {
+		var zero T
+		return zero, err
+	}
+	return dist, nil
+}
+
+// MustDecode The same as Decode, but panic if error.
+func MustDecode[T any](value cadence.Value) T {
+	dist, err := Decode[T](value)
+	if err != nil {
+		panic(err)
+	}
+	return dist
+}
+
+// DecodeSlice Convert cadence array to []T, elements are converted the same as ToGo.
+func DecodeSlice[T any](value cadence.Value) ([]T, error) {
+	return Decode[[]T](value)
+}
+
+// DecodeMap Convert cadence dictionary to map[K]V, keys and values are converted the same as ToGo.
+func DecodeMap[K comparable, V any](value cadence.Value) (map[K]V, error) {
+	return Decode[map[K]V](value)
+}
+
+// Encode Convert go value of type T to cadence value, the same as ToCadence.
+func Encode[T any](value T) (cadence.Value, error) {
+	return ToCadence(value)
+}
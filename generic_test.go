@@ -0,0 +1,125 @@
+package godence
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleDecode() {
+	name, err := Decode[string](cadence.String("LemonNeko"))
+	fmt.Printf("name: %s, err: %v", name, err)
+	//Output: name: LemonNeko, err: <nil>
+}
+
+func TestDecode(t *testing.T) {
+	t.Run("script result", func(t *testing.T) {
+		assert := assert.New(t)
+		script := []byte(`pub fun main(): UInt256 { return 15 }`)
+
+		ret, err := flowCli.ExecuteScriptAtLatestBlock(context.Background(), script, nil)
+		assert.NoError(err)
+
+		i, err := Decode[*big.Int](ret)
+		assert.NoError(err)
+		assert.Equal(big.NewInt(15), i)
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		type simpleStruct struct {
+			MyName string `godence:"myName"`
+		}
+		assert := assert.New(t)
+		value := cadence.NewStruct([]cadence.Value{cadence.String("LemonNeko")}).WithType(&cadence.StructType{
+			QualifiedIdentifier: "SimpleStruct",
+			Fields:              []cadence.Field{{Identifier: "myName", Type: cadence.StringType{}}},
+		})
+
+		dist, err := Decode[simpleStruct](value)
+		assert.NoError(err)
+		assert.Equal(simpleStruct{MyName: "LemonNeko"}, dist)
+
+		ptr, err := Decode[*simpleStruct](value)
+		assert.NoError(err)
+		assert.Equal(&simpleStruct{MyName: "LemonNeko"}, ptr)
+	})
+
+	t.Run("optional", func(t *testing.T) {
+		assert := assert.New(t)
+
+		dist, err := Decode[*uint8](cadence.NewOptional(nil))
+		assert.NoError(err)
+		assert.Nil(dist)
+
+		dist, err = Decode[*uint8](cadence.NewOptional(cadence.NewUInt8(15)))
+		assert.NoError(err)
+		assert.Equal(uint8(15), *dist)
+	})
+
+	t.Run("error returns zero value", func(t *testing.T) {
+		assert := assert.New(t)
+
+		dist, err := Decode[[]string](cadence.NewArray([]cadence.Value{cadence.String("LemonNeko"), cadence.NewUInt8(15)}))
+		assert.EqualError(err, "[1]: cannot convert UInt8 to string")
+		assert.Nil(dist)
+	})
+}
+
+func TestMustDecode(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("LemonNeko", MustDecode[string](cadence.String("LemonNeko")))
+	assert.PanicsWithError("cannot convert String to bool", func() {
+		MustDecode[bool](cadence.String("LemonNeko"))
+	})
+}
+
+func TestDecodeSlice(t *testing.T) {
+	assert := assert.New(t)
+	value := cadence.NewArray([]cadence.Value{cadence.NewUInt64(1), cadence.NewUInt64(2)})
+
+	dist, err := DecodeSlice[uint64](value)
+	assert.NoError(err)
+	assert.Equal([]uint64{1, 2}, dist)
+
+	_, err = DecodeSlice[uint64](cadence.String("LemonNeko"))
+	assert.ErrorIs(err, ErrTypeMismatch)
+
+	// fixed-size array by Decode
+	array, err := Decode[[2]uint64](value)
+	assert.NoError(err)
+	assert.Equal([2]uint64{1, 2}, array)
+
+	_, err = Decode[[3]uint64](value)
+	assert.ErrorIs(err, ErrTypeMismatch)
+}
+
+func TestDecodeMap(t *testing.T) {
+	assert := assert.New(t)
+	value := cadence.NewDictionary([]cadence.KeyValuePair{
+		{Key: cadence.String("LemonNeko"), Value: cadence.NewOptional(cadence.NewUInt8(18))},
+		{Key: cadence.String("Nobody"), Value: cadence.NewOptional(nil)},
+	})
+
+	dist, err := DecodeMap[string, *uint8](value)
+	assert.NoError(err)
+	assert.Len(dist, 2)
+	assert.Equal(uint8(18), *dist["LemonNeko"])
+	assert.Nil(dist["Nobody"])
+}
+
+func TestEncode(t *testing.T) {
+	assert := assert.New(t)
+
+	cadenceValue, err := Encode(UFix64(50000000))
+	assert.NoError(err)
+	assert.Equal(cadence.UFix64(50000000), cadenceValue)
+
+	cadenceValue, err = Encode([]string{"LemonNeko"})
+	assert.NoError(err)
+	assert.Equal(cadence.NewArray([]cadence.Value{cadence.String("LemonNeko")}), cadenceValue)
+}
@@ -0,0 +1,43 @@
+package godence
+
+import (
+	"reflect"
+
+	"github.com/onflow/cadence"
+)
+
+// CadenceMarshaler Go types implement it can convert themselves to cadence value.
+// ToCadence and ToCadenceAs check it before the built-in rules, including struct fields, slice elements and map entries.
+// Functions registered by RegisterEncoder take precedence over it.
+type CadenceMarshaler interface {
+	MarshalCadence() (cadence.Value, error)
+}
+
+// CadenceUnmarshaler Go types implement it can convert cadence value to themselves.
+// ToGo checks it before the built-in rules, including struct fields, slice elements and map entries.
+// Functions registered by RegisterDecoder take precedence over it.
+// The cadence value may be an optional if the go type is not a pointer.
+type CadenceUnmarshaler interface {
+	UnmarshalCadence(value cadence.Value) error
+}
+
+var cadenceMarshalerType = reflect.TypeOf((*CadenceMarshaler)(nil)).Elem()
+
+// marshalerOf. get CadenceMarshaler of value, method with pointer receiver is supported too.
+// Nil pointer is not a marshaler.
+func marshalerOf(value any) (CadenceMarshaler, bool) {
+	v := reflect.ValueOf(value)
+	if !v.IsValid() || (v.Kind() == reflect.Pointer && v.IsNil()) {
+		return nil, false
+	}
+	if m, ok := value.(CadenceMarshaler); ok {
+		return m, true
+	}
+	// method with pointer receiver, copy value to an addressable one
+	if reflect.PointerTo(v.Type()).Implements(cadenceMarshalerType) {
+		ptr := reflect.New(v.Type())
+		ptr.Elem().Set(v)
+		return ptr.Interface().(CadenceMarshaler), true
+	}
+	return nil, false
+}
@@ -0,0 +1,185 @@
+package godence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+)
+
+// tokenAmount. amount in decimal string, UFix64 in cadence.
+type tokenAmount string
+
+func (a tokenAmount) MarshalCadence() (cadence.Value, error) {
+	return cadence.NewUFix64(string(a))
+}
+
+func (a *tokenAmount) UnmarshalCadence(value cadence.Value) error {
+	if optional, ok := value.(cadence.Optional); ok && optional.Value != nil {
+		value = optional.Value
+	}
+	ufix64, ok := value.(cadence.UFix64)
+	if !ok {
+		return fmt.Errorf("token amount should be UFix64, got %s", value.Type().ID())
+	}
+	*a = tokenAmount(strings.TrimRight(strings.TrimRight(ufix64.String(), "0"), "."))
+	return nil
+}
+
+// nftID. "#" prefixed id, UInt64 in cadence. Methods have pointer receiver.
+type nftID struct {
+	id uint64
+}
+
+func (n *nftID) MarshalCadence() (cadence.Value, error) {
+	return cadence.NewUInt64(n.id), nil
+}
+
+func (n *nftID) UnmarshalCadence(value cadence.Value) error {
+	id, ok := value.(cadence.UInt64)
+	if !ok {
+		return errors.New("nft id should be UInt64")
+	}
+	n.id = uint64(id)
+	return nil
+}
+
+func (n nftID) String() string {
+	return "#" + strconv.FormatUint(n.id, 10)
+}
+
+func ExampleCadenceMarshaler() {
+	cadenceValue, err := ToCadence(tokenAmount("1.5"))
+	fmt.Printf("type id: %s, value: %s, err: %v", cadenceValue.Type().ID(), cadenceValue, err)
+	//Output: type id: UFix64, value: 1.50000000, err: <nil>
+}
+
+func TestCadenceMarshaler(t *testing.T) {
+	t.Run("script argument", func(t *testing.T) {
+		assert := assert.New(t)
+		script := []byte(`pub fun main(arg: UFix64): UFix64 { return arg * 2.0 }`)
+
+		cadenceValue, err := ToCadence(tokenAmount("0.5"))
+		assert.NoError(err)
+
+		args := []cadence.Value{cadenceValue}
+		ret, err := flowCli.ExecuteScriptAtLatestBlock(context.Background(), script, args)
+		assert.NoError(err)
+
+		var dist tokenAmount
+		assert.NoError(ToGo(ret, &dist))
+		assert.Equal(tokenAmount("1"), dist)
+	})
+
+	t.Run("pointer receiver", func(t *testing.T) {
+		assert := assert.New(t)
+
+		cadenceValue, err := ToCadence(nftID{id: 15})
+		assert.NoError(err)
+		assert.Equal(cadence.NewUInt64(15), cadenceValue)
+
+		cadenceValue, err = ToCadence(&nftID{id: 15})
+		assert.NoError(err)
+		assert.Equal(cadence.NewUInt64(15), cadenceValue)
+	})
+
+	t.Run("slice elements and map values", func(t *testing.T) {
+		assert := assert.New(t)
+
+		cadenceValue, err := ToCadence([]nftID{{id: 1}, {id: 2}})
+		assert.NoError(err)
+		assert.Equal(cadence.NewArray([]cadence.Value{cadence.NewUInt64(1), cadence.NewUInt64(2)}), cadenceValue)
+
+		cadenceValue, err = ToCadence(map[string]tokenAmount{"LemonNeko": "1.5"})
+		assert.NoError(err)
+		assert.Equal(cadence.NewDictionary([]cadence.KeyValuePair{{Key: cadence.String("LemonNeko"), Value: cadence.UFix64(150000000)}}), cadenceValue)
+	})
+
+	t.Run("struct fields", func(t *testing.T) {
+		type balance struct {
+			ID     nftID       `godence:"id"`
+			Amount tokenAmount `godence:"amount"`
+		}
+		assert := assert.New(t)
+		typ := &cadence.StructType{
+			QualifiedIdentifier: "Balance",
+			Fields: []cadence.Field{
+				{Identifier: "id", Type: cadence.UInt64Type{}},
+				{Identifier: "amount", Type: cadence.UFix64Type{}},
+			},
+		}
+
+		cadenceValue, err := ToCadenceAs(balance{ID: nftID{id: 15}, Amount: "1.5"}, typ)
+		assert.NoError(err)
+		assert.Equal(cadence.NewStruct([]cadence.Value{cadence.NewUInt64(15), cadence.UFix64(150000000)}).WithType(typ), cadenceValue)
+	})
+
+	t.Run("ToCadenceAs checks the type", func(t *testing.T) {
+		assert := assert.New(t)
+
+		cadenceValue, err := ToCadenceAs(tokenAmount("1.5"), cadence.OptionalType{Type: cadence.UFix64Type{}})
+		assert.NoError(err)
+		assert.Equal(cadence.NewOptional(cadence.UFix64(150000000)), cadenceValue)
+
+		_, err = ToCadenceAs(tokenAmount("1.5"), cadence.Fix64Type{})
+		assert.EqualError(err, "cannot convert cadence.UFix64 to Fix64")
+	})
+
+	t.Run("error", func(t *testing.T) {
+		assert := assert.New(t)
+
+		_, err := ToCadence([]tokenAmount{"LemonNeko"})
+		assert.ErrorContains(err, "[0]: ")
+	})
+}
+
+func TestCadenceUnmarshaler(t *testing.T) {
+	t.Run("struct fields", func(t *testing.T) {
+		type balance struct {
+			ID     *nftID      `godence:"id"`
+			Amount tokenAmount `godence:"amount"`
+		}
+		assert := assert.New(t)
+		value := cadence.NewStruct([]cadence.Value{
+			cadence.NewOptional(cadence.NewUInt64(15)),
+			cadence.NewOptional(cadence.UFix64(150000000)),
+		}).WithType(&cadence.StructType{
+			QualifiedIdentifier: "Balance",
+			Fields: []cadence.Field{
+				{Identifier: "id", Type: cadence.OptionalType{Type: cadence.UInt64Type{}}},
+				{Identifier: "amount", Type: cadence.OptionalType{Type: cadence.UFix64Type{}}},
+			},
+		})
+
+		dist := balance{}
+		assert.NoError(ToGo(value, &dist))
+		assert.Equal("#15", dist.ID.String())
+		assert.Equal(tokenAmount("1.5"), dist.Amount)
+	})
+
+	t.Run("slice elements and map values", func(t *testing.T) {
+		assert := assert.New(t)
+
+		ids, err := DecodeSlice[nftID](cadence.NewArray([]cadence.Value{cadence.NewUInt64(1), cadence.NewUInt64(2)}))
+		assert.NoError(err)
+		assert.Equal([]nftID{{id: 1}, {id: 2}}, ids)
+
+		amounts, err := DecodeMap[string, tokenAmount](cadence.NewDictionary([]cadence.KeyValuePair{
+			{Key: cadence.String("LemonNeko"), Value: cadence.UFix64(150000000)},
+		}))
+		assert.NoError(err)
+		assert.Equal(map[string]tokenAmount{"LemonNeko": "1.5"}, amounts)
+	})
+
+	t.Run("error has path", func(t *testing.T) {
+		assert := assert.New(t)
+
+		_, err := DecodeSlice[nftID](cadence.NewArray([]cadence.Value{cadence.String("LemonNeko")}))
+		assert.EqualError(err, "[0]: nft id should be UInt64")
+	})
+}
@@ -0,0 +1,265 @@
+package godence
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/onflow/cadence"
+)
+
+// NamingStrategy How to name the cadence field of a go struct field without godence tag.
+type NamingStrategy int
+
+const (
+	// NamingExact Use go field name, e.g. MyName is MyName. It is the default.
+	NamingExact NamingStrategy = iota
+	// NamingLowerCamel Lower the first word of go field name, e.g. MyName is myName, URLValue is urlValue.
+	NamingLowerCamel
+	// NamingCaseInsensitive Match go field name and cadence field name ignoring case, e.g. MyName matches myName.
+	// Go field name is used when the cadence type is unknown, e.g. ToCadence.
+	NamingCaseInsensitive
+	// NamingSnakeCase Split go field name into lower case words, e.g. MyName is my_name, URLValue is url_value.
+	NamingSnakeCase
+)
+
+// fieldName. cadence field name of go field name.
+func (n NamingStrategy) fieldName(name string) string {
+	switch n {
+	case NamingLowerCamel:
+		return lowerCamel(name)
+	case NamingSnakeCase:
+		return snakeCase(name)
+	}
+	return name
+}
+
+// matchKey. go fields and cadence fields with the same key are matched.
+func (n NamingStrategy) matchKey(name string) string {
+	if n == NamingCaseInsensitive {
+		return strings.ToLower(name)
+	}
+	return name
+}
+
+// fieldNaming. how to name cadence fields of go fields without godence tag.
+type fieldNaming struct {
+	strategy NamingStrategy
+	// use name in json tag if any
+	jsonTags bool
+}
+
+// lowerCamel. lower the leading upper case letters, the last one is kept if it starts the next word.
+func lowerCamel(name string) string {
+	runes := []rune(name)
+	n := 0
+	for n < len(runes) && unicode.IsUpper(runes[n]) {
+		n++
+	}
+	// e.g. URLValue, V starts the next word
+	if n > 1 && n < len(runes) && unicode.IsLower(runes[n]) {
+		n--
+	}
+	for i := 0; i < n; i++ {
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
+
+// snakeCase. a new word starts at an upper case letter after a lower case letter or a digit,
+// or at the last upper case letter followed by a lower case letter, e.g. URLValue is url_value.
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var builder strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				builder.WriteByte('_')
+			}
+		}
+		builder.WriteRune(unicode.ToLower(r))
+	}
+	return builder.String()
+}
+
+// IntegerPolicy Which cadence integers can be converted to a go integer.
+type IntegerPolicy int
+
+const (
+	// IntegerRangeChecked Any cadence integer can convert to any go integer if the value fits. It is the default.
+	IntegerRangeChecked IntegerPolicy = iota
+	// IntegerExact Cadence integer should have the same size and sign as the go integer,
+	// e.g. UInt8 and Word8 to uint8, Int to int, Int128 to *big.Int.
+	IntegerExact
+)
+
+// MapOrder Order of cadence dictionary entries converted from go map.
+type MapOrder int
+
+const (
+	// MapOrderRandom Iteration order of go map, it is random. It is the default.
+	MapOrderRandom MapOrder = iota
+	// MapOrderSorted Sorted by keys, so the result is deterministic.
+	MapOrderSorted
+)
+
+// options. options of Decoder and Encoder.
+type options struct {
+	strict          bool
+	unmappedHandler UnmappedFieldFunc
+	requireAll      bool
+	naming          fieldNaming
+	integerPolicy   IntegerPolicy
+	mapOrder        MapOrder
+	// functions of WithEncoderFunc and WithDecoderFunc, moved to the registry of Encoder and Decoder by the constructor
+	encoderFuncs map[reflect.Type]EncoderFunc
+	decoderFuncs map[reflect.Type]DecoderFunc
+}
+
+// Option Configure a Decoder or an Encoder, options it does not use are ignored.
+type Option func(*options)
+
+// WithStrict Fields of go struct and cadence composite should match exactly.
+// Decoder returns FieldNotFoundError if a cadence field has no go field,
+// Encoder returns FieldNotFoundError if an exported go field has no cadence field of the expected type.
+func WithStrict() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
+// UnmappedFieldFunc Called with cadence field which has no go field, typ is type of the cadence composite.
+// Return error to stop decoding.
+type UnmappedFieldFunc func(typ cadence.Type, field string, value cadence.Value) error
+
+// WithUnmappedFieldHandler Report cadence fields without go field to the function, used by Decoder.
+// e.g. log fields added by a contract upgrade. It is called before the error of WithStrict.
+func WithUnmappedFieldHandler(fn UnmappedFieldFunc) Option {
+	return func(o *options) {
+		o.unmappedHandler = fn
+	}
+}
+
+// WithRequireAllFields Every go field should be set by a cadence field, used by Decoder.
+// Decoder returns FieldNotFoundError for missing cadence fields, even if the go field is tagged optional.
+func WithRequireAllFields() Option {
+	return func(o *options) {
+		o.requireAll = true
+	}
+}
+
+// WithNaming Name cadence fields of go fields without godence tag by the strategy, used by Decoder and Encoder.
+func WithNaming(naming NamingStrategy) Option {
+	return func(o *options) {
+		o.naming.strategy = naming
+	}
+}
+
+// WithJSONTags Use name in json tag for go fields without godence tag, used by Decoder and Encoder.
+// e.g. `json:"myName,omitempty"` is myName, other options of json tag are ignored.
+func WithJSONTags() Option {
+	return func(o *options) {
+		o.naming.jsonTags = true
+	}
+}
+
+// WithIntegerPolicy Which cadence integers can be converted to a go integer, used by Decoder.
+func WithIntegerPolicy(policy IntegerPolicy) Option {
+	return func(o *options) {
+		o.integerPolicy = policy
+	}
+}
+
+// WithMapOrder Order of cadence dictionary entries converted from go map, used by Encoder.
+func WithMapOrder(order MapOrder) Option {
+	return func(o *options) {
+		o.mapOrder = order
+	}
+}
+
+// WithEncoderFunc Register a function to convert go values of type t, the same as Encoder.RegisterEncoder.
+func WithEncoderFunc(t reflect.Type, fn EncoderFunc) Option {
+	return func(o *options) {
+		if o.encoderFuncs == nil {
+			o.encoderFuncs = map[reflect.Type]EncoderFunc{}
+		}
+		o.encoderFuncs[t] = fn
+	}
+}
+
+// WithDecoderFunc Register a function to convert cadence values to go type t, the same as Decoder.RegisterDecoder.
+func WithDecoderFunc(t reflect.Type, fn DecoderFunc) Option {
+	return func(o *options) {
+		if o.decoderFuncs == nil {
+			o.decoderFuncs = map[reflect.Type]DecoderFunc{}
+		}
+		o.decoderFuncs[t] = fn
+	}
+}
+
+// isExactInteger. check if go type t has the same size and sign as cadence integer, used by IntegerExact.
+// Fix64 and UFix64 are the same as Int64 and UInt64. Return true if value or t is not an integer.
+func isExactInteger(value cadence.Value, t reflect.Type) bool {
+	var kind reflect.Kind
+	switch value.(type) {
+	case cadence.Int8:
+		kind = reflect.Int8
+	case cadence.Int16:
+		kind = reflect.Int16
+	case cadence.Int32:
+		kind = reflect.Int32
+	case cadence.Int64, cadence.Fix64:
+		kind = reflect.Int64
+	case cadence.Int:
+		kind = reflect.Int
+	case cadence.UInt8, cadence.Word8:
+		kind = reflect.Uint8
+	case cadence.UInt16, cadence.Word16:
+		kind = reflect.Uint16
+	case cadence.UInt32, cadence.Word32:
+		kind = reflect.Uint32
+	case cadence.UInt64, cadence.Word64, cadence.UFix64:
+		kind = reflect.Uint64
+	case cadence.UInt:
+		kind = reflect.Uint
+	case cadence.Int128, cadence.Int256, cadence.UInt128, cadence.UInt256:
+		// *big.Int only
+		kind = reflect.Invalid
+	default:
+		return true
+	}
+	if t == reflect.TypeOf(&big.Int{}) {
+		_, ok := bigIntegerOf(value)
+		return ok
+	}
+	if !isGoIntegerKind(t.Kind()) {
+		return true
+	}
+	return t.Kind() == kind
+}
+
+// sortMapKeys. sort keys of go map by value, used by MapOrderSorted.
+// Keys of other kinds are sorted by their string representation.
+func sortMapKeys(keys []reflect.Value) {
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		switch a.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return a.Int() < b.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return a.Uint() < b.Uint()
+		case reflect.Float32, reflect.Float64:
+			return a.Float() < b.Float()
+		case reflect.String:
+			return a.String() < b.String()
+		case reflect.Bool:
+			return !a.Bool() && b.Bool()
+		}
+		return fmt.Sprint(a.Interface()) < fmt.Sprint(b.Interface())
+	})
+}
@@ -0,0 +1,471 @@
+package godence
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+)
+
+// optionsTestUser. go struct without godence tag.
+type optionsTestUser struct {
+	MyName   string
+	URLValue string
+	ID       uint64
+}
+
+// optionsTestUserType. cadence struct type of optionsTestUser, in lower camel case.
+var optionsTestUserType = &cadence.StructType{
+	QualifiedIdentifier: "OptionsTest.User",
+	Fields: []cadence.Field{
+		{Identifier: "myName", Type: cadence.StringType{}},
+		{Identifier: "urlValue", Type: cadence.StringType{}},
+		{Identifier: "id", Type: cadence.UInt64Type{}},
+	},
+}
+
+func ExampleNewDecoder() {
+	type user struct {
+		MyName string
+	}
+	decoder := NewDecoder(WithNaming(NamingLowerCamel), WithStrict())
+	value := cadence.NewStruct([]cadence.Value{cadence.String("LemonNeko")}).WithType(&cadence.StructType{
+		QualifiedIdentifier: "User",
+		Fields:              []cadence.Field{{Identifier: "myName", Type: cadence.StringType{}}},
+	})
+	dist := user{}
+	err := decoder.Decode(value, &dist)
+	fmt.Printf("name: %s, err: %v", dist.MyName, err)
+	//Output: name: LemonNeko, err: <nil>
+}
+
+func TestLowerCamel(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("myName", lowerCamel("MyName"))
+	assert.Equal("urlValue", lowerCamel("URLValue"))
+	assert.Equal("id", lowerCamel("ID"))
+	assert.Equal("nftid", lowerCamel("NFTID"))
+	assert.Equal("id2", lowerCamel("ID2"))
+	assert.Equal("x", lowerCamel("X"))
+	assert.Equal("", lowerCamel(""))
+}
+
+func TestSnakeCase(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("my_name", snakeCase("MyName"))
+	assert.Equal("url_value", snakeCase("URLValue"))
+	assert.Equal("user_id", snakeCase("UserID"))
+	assert.Equal("id", snakeCase("ID"))
+	assert.Equal("id2", snakeCase("ID2"))
+	assert.Equal("value2_name", snakeCase("Value2Name"))
+	assert.Equal("x", snakeCase("X"))
+	assert.Equal("", snakeCase(""))
+}
+
+func TestWithNaming(t *testing.T) {
+	t.Run("decode", func(t *testing.T) {
+		assert := assert.New(t)
+		value := cadence.NewStruct([]cadence.Value{
+			cadence.String("LemonNeko"),
+			cadence.String("https://example.com"),
+			cadence.NewUInt64(15),
+		}).WithType(optionsTestUserType)
+
+		dist := optionsTestUser{}
+		assert.NoError(NewDecoder(WithNaming(NamingLowerCamel)).Decode(value, &dist))
+		assert.Equal(optionsTestUser{MyName: "LemonNeko", URLValue: "https://example.com", ID: 15}, dist)
+
+		// exact by default
+		assert.EqualError(ToGo(value, &dist), "cannot find field named MyName in OptionsTest.User")
+	})
+
+	t.Run("encode", func(t *testing.T) {
+		assert := assert.New(t)
+		encoder := NewEncoder(WithNaming(NamingLowerCamel))
+		user := optionsTestUser{MyName: "LemonNeko", URLValue: "https://example.com", ID: 15}
+
+		cadenceValue, err := encoder.EncodeAs(user, optionsTestUserType)
+		assert.NoError(err)
+		assert.Equal(cadence.NewStruct([]cadence.Value{
+			cadence.String("LemonNeko"),
+			cadence.String("https://example.com"),
+			cadence.NewUInt64(15),
+		}).WithType(optionsTestUserType), cadenceValue)
+
+		assert.NoError(RegisterStruct("A.f8d6e0586b0a20c7.OptionsTest.User", optionsTestUser{}))
+		cadenceValue, err = encoder.Encode(user)
+		assert.NoError(err)
+		assert.Equal("myName", cadenceValue.(cadence.Struct).StructType.Fields[0].Identifier)
+	})
+
+	t.Run("tag takes precedence", func(t *testing.T) {
+		type user struct {
+			MyName string `godence:"Name"`
+		}
+		assert := assert.New(t)
+		value := cadence.NewStruct([]cadence.Value{cadence.String("LemonNeko")}).WithType(&cadence.StructType{
+			QualifiedIdentifier: "User",
+			Fields:              []cadence.Field{{Identifier: "Name", Type: cadence.StringType{}}},
+		})
+
+		dist := user{}
+		assert.NoError(NewDecoder(WithNaming(NamingLowerCamel)).Decode(value, &dist))
+		assert.Equal("LemonNeko", dist.MyName)
+	})
+}
+
+func TestNamingStrategies(t *testing.T) {
+	// struct without godence tag, one field has json tag
+	type user struct {
+		MyName   string
+		UserID   uint64
+		Nickname string `json:"nick,omitempty"`
+	}
+	newValue := func(names ...string) cadence.Struct {
+		return cadence.NewStruct([]cadence.Value{
+			cadence.String("LemonNeko"),
+			cadence.NewUInt64(15),
+			cadence.String("Neko"),
+		}).WithType(&cadence.StructType{
+			QualifiedIdentifier: "User",
+			Fields: []cadence.Field{
+				{Identifier: names[0], Type: cadence.StringType{}},
+				{Identifier: names[1], Type: cadence.UInt64Type{}},
+				{Identifier: names[2], Type: cadence.StringType{}},
+			},
+		})
+	}
+	expect := user{MyName: "LemonNeko", UserID: 15, Nickname: "Neko"}
+	cases := []struct {
+		name    string
+		options []Option
+		value   cadence.Struct
+	}{
+		{"exact", []Option{WithNaming(NamingExact)}, newValue("MyName", "UserID", "Nickname")},
+		{"lower camel", []Option{WithNaming(NamingLowerCamel)}, newValue("myName", "userID", "nickname")},
+		{"case insensitive", []Option{WithNaming(NamingCaseInsensitive)}, newValue("myname", "userId", "NICKNAME")},
+		{"snake case", []Option{WithNaming(NamingSnakeCase)}, newValue("my_name", "user_id", "nickname")},
+		{"json tags", []Option{WithNaming(NamingLowerCamel), WithJSONTags()}, newValue("myName", "userID", "nick")},
+		{"json tags case insensitive", []Option{WithNaming(NamingCaseInsensitive), WithJSONTags()}, newValue("myName", "userId", "NICK")},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			dist := user{}
+			assert.NoError(NewDecoder(c.options...).Decode(c.value, &dist))
+			assert.Equal(expect, dist)
+
+			cadenceValue, err := NewEncoder(c.options...).EncodeAs(expect, c.value.StructType)
+			assert.NoError(err)
+			assert.Equal(c.value, cadenceValue)
+		})
+	}
+
+	t.Run("ambiguous", func(t *testing.T) {
+		assert := assert.New(t)
+		value := newValue("myName", "userID", "MyName")
+
+		dist := user{}
+		err := NewDecoder(WithNaming(NamingCaseInsensitive)).Decode(value, &dist)
+		assert.ErrorIs(err, ErrAmbiguousField)
+		assert.EqualError(err, "ambiguous fields myName and MyName in User")
+
+		_, err = NewEncoder(WithNaming(NamingCaseInsensitive)).EncodeAs(expect, value.StructType)
+		assert.ErrorIs(err, ErrAmbiguousField)
+
+		// not ambiguous if names are exact
+		_, err = NewEncoder(WithNaming(NamingLowerCamel)).EncodeAs(struct {
+			MyName   string
+			UserID   uint64
+			Nickname string `godence:"MyName"`
+		}{}, value.StructType)
+		assert.NoError(err)
+	})
+
+	t.Run("ambiguous go fields", func(t *testing.T) {
+		type probe struct {
+			MyName string
+			Other  string `json:"myName"`
+		}
+		assert := assert.New(t)
+		options := []Option{WithNaming(NamingLowerCamel), WithJSONTags()}
+		value := cadence.NewStruct([]cadence.Value{cadence.String("LemonNeko")}).WithType(&cadence.StructType{
+			QualifiedIdentifier: "Probe",
+			Fields:              []cadence.Field{{Identifier: "myName", Type: cadence.StringType{}}},
+		})
+
+		dist := probe{}
+		err := NewDecoder(options...).Decode(value, &dist)
+		assert.ErrorIs(err, ErrAmbiguousField)
+		assert.EqualError(err, "ambiguous fields MyName and Other in go struct godence.probe")
+		assert.Equal(probe{}, dist)
+
+		_, err = NewEncoder(options...).EncodeAs(probe{MyName: "a", Other: "b"}, value.StructType)
+		assert.ErrorIs(err, ErrAmbiguousField)
+
+		assert.NoError(RegisterStruct("Probe", probe{}))
+		_, err = NewEncoder(options...).Encode(probe{MyName: "a", Other: "b"})
+		assert.ErrorIs(err, ErrAmbiguousField)
+
+		// the same field name is allowed without json tags
+		_, err = NewEncoder(WithNaming(NamingLowerCamel)).Encode(probe{MyName: "a", Other: "b"})
+		assert.NoError(err)
+	})
+
+	t.Run("nested path", func(t *testing.T) {
+		type family struct {
+			Members []user
+		}
+		assert := assert.New(t)
+		value := cadence.NewStruct([]cadence.Value{
+			cadence.NewArray([]cadence.Value{newValue("myName", "userID", "MyName")}),
+		}).WithType(&cadence.StructType{
+			QualifiedIdentifier: "Family",
+			Fields:              []cadence.Field{{Identifier: "members", Type: cadence.VariableSizedArrayType{ElementType: cadence.AnyStructType{}}}},
+		})
+
+		dist := family{}
+		err := NewDecoder(WithNaming(NamingCaseInsensitive)).Decode(value, &dist)
+		var ambiguousErr *AmbiguousFieldError
+		assert.ErrorAs(err, &ambiguousErr)
+		assert.Equal("Family.members[0]", ambiguousErr.Path)
+	})
+}
+
+func TestWithStrict(t *testing.T) {
+	type user struct {
+		MyName string `godence:"myName"`
+	}
+	typ := &cadence.StructType{
+		QualifiedIdentifier: "User",
+		Fields: []cadence.Field{
+			{Identifier: "myName", Type: cadence.StringType{}},
+			{Identifier: "age", Type: cadence.UInt8Type{}},
+		},
+	}
+
+	t.Run("decode", func(t *testing.T) {
+		assert := assert.New(t)
+		value := cadence.NewStruct([]cadence.Value{cadence.String("LemonNeko"), cadence.NewUInt8(18)}).WithType(typ)
+
+		dist := user{}
+		assert.NoError(ToGo(value, &dist))
+
+		err := NewDecoder(WithStrict()).Decode(value, &dist)
+		assert.ErrorIs(err, ErrFieldNotFound)
+		assert.EqualError(err, "cannot find field named age in go struct godence.user")
+	})
+
+	t.Run("decode script result", func(t *testing.T) {
+		assert := assert.New(t)
+		script := []byte(`
+		pub struct User {
+			pub let myName: String
+			pub let age: UInt8
+			init() {
+				self.myName = "LemonNeko"
+				self.age = 18
+			}
+		}
+		pub fun main(): User { return User() }`)
+
+		ret, err := flowCli.ExecuteScriptAtLatestBlock(context.Background(), script, nil)
+		assert.NoError(err)
+
+		dist := user{}
+		assert.ErrorIs(NewDecoder(WithStrict()).Decode(ret, &dist), ErrFieldNotFound)
+	})
+
+	t.Run("encode", func(t *testing.T) {
+		type profile struct {
+			MyName string `godence:"myName"`
+			Age    uint8  `godence:"age"`
+			Email  string `godence:"email"`
+		}
+		assert := assert.New(t)
+		value := profile{MyName: "LemonNeko", Age: 18}
+
+		_, err := ToCadenceAs(value, typ)
+		assert.NoError(err)
+
+		_, err = NewEncoder(WithStrict()).EncodeAs(value, typ)
+		assert.ErrorIs(err, ErrFieldNotFound)
+		assert.EqualError(err, "cannot find field named email in User")
+	})
+}
+
+func TestWithUnmappedFieldHandler(t *testing.T) {
+	// only some fields of manyTypeEvent
+	type event struct {
+		IntValue    int64  `godence:"intValue"`
+		StringValue string `godence:"stringValue"`
+	}
+
+	t.Run("report", func(t *testing.T) {
+		assert := assert.New(t)
+		unmapped := map[string]cadence.Value{}
+		decoder := NewDecoder(WithUnmappedFieldHandler(func(typ cadence.Type, field string, value cadence.Value) error {
+			assert.Equal("PlanTest.ManyType", typ.ID())
+			unmapped[field] = value
+			return nil
+		}))
+
+		dist := event{}
+		assert.NoError(decoder.Decode(manyTypeEvent(), &dist))
+		assert.Equal(event{IntValue: -15, StringValue: "LemonNeko"}, dist)
+		assert.Equal(map[string]cadence.Value{
+			"boolValue": cadence.NewBool(true),
+			"uintValue": cadence.NewUInt64(15),
+			"address":   cadence.NewAddress([8]uint8{0xf8, 0xd6, 0xe0, 0x58, 0x6b, 0x0a, 0x20, 0xc7}),
+		}, unmapped)
+	})
+
+	t.Run("stop decoding", func(t *testing.T) {
+		type events struct {
+			Events []event `godence:"events"`
+		}
+		assert := assert.New(t)
+		decoder := NewDecoder(WithUnmappedFieldHandler(func(typ cadence.Type, field string, value cadence.Value) error {
+			return fmt.Errorf("unknown field %s", field)
+		}))
+		value := cadence.NewStruct([]cadence.Value{cadence.NewArray([]cadence.Value{manyTypeEvent()})}).WithType(&cadence.StructType{
+			QualifiedIdentifier: "Events",
+			Fields:              []cadence.Field{{Identifier: "events", Type: cadence.VariableSizedArrayType{ElementType: cadence.AnyStructType{}}}},
+		})
+
+		dist := events{}
+		assert.EqualError(decoder.Decode(value, &dist), "Events.events[0]: unknown field boolValue")
+	})
+
+	t.Run("with strict", func(t *testing.T) {
+		assert := assert.New(t)
+		count := 0
+		decoder := NewDecoder(WithStrict(), WithUnmappedFieldHandler(func(typ cadence.Type, field string, value cadence.Value) error {
+			count++
+			return nil
+		}))
+
+		dist := event{}
+		assert.EqualError(decoder.Decode(manyTypeEvent(), &dist), "cannot find field named boolValue in go struct godence.event")
+		assert.Equal(3, count)
+	})
+}
+
+func TestWithRequireAllFields(t *testing.T) {
+	type user struct {
+		MyName string `godence:"myName"`
+		Email  string `godence:"email,optional"`
+	}
+	assert := assert.New(t)
+	value := cadence.NewStruct([]cadence.Value{cadence.String("LemonNeko")}).WithType(&cadence.StructType{
+		QualifiedIdentifier: "User",
+		Fields:              []cadence.Field{{Identifier: "myName", Type: cadence.StringType{}}},
+	})
+
+	dist := user{}
+	assert.NoError(ToGo(value, &dist))
+	assert.Equal("LemonNeko", dist.MyName)
+
+	err := NewDecoder(WithRequireAllFields()).Decode(value, &dist)
+	assert.ErrorIs(err, ErrFieldNotFound)
+	assert.EqualError(err, "cannot find field named email in User")
+}
+
+func TestWithIntegerPolicy(t *testing.T) {
+	assert := assert.New(t)
+	decoder := NewDecoder(WithIntegerPolicy(IntegerExact))
+
+	var i8 int8
+	assert.NoError(ToGo(cadence.NewInt16(15), &i8))
+	assert.ErrorIs(decoder.Decode(cadence.NewInt16(15), &i8), ErrTypeMismatch)
+	assert.NoError(decoder.Decode(cadence.NewInt8(15), &i8))
+
+	var u8 uint8
+	assert.NoError(decoder.Decode(cadence.NewWord8(15), &u8))
+	assert.EqualError(decoder.Decode(cadence.NewUInt64(15), &u8), "cannot convert UInt64 to uint8")
+
+	var u64 uint64
+	assert.NoError(decoder.Decode(cadence.UFix64(150000000), &u64))
+	assert.Equal(uint64(150000000), u64)
+
+	var i int
+	assert.NoError(decoder.Decode(cadence.NewInt(15), &i))
+	assert.Error(decoder.Decode(cadence.NewInt64(15), &i))
+
+	var bigInt *big.Int
+	assert.NoError(decoder.Decode(cadence.NewUInt(15), &bigInt))
+	assert.Error(decoder.Decode(cadence.NewUInt64(15), &bigInt))
+	var i64 int64
+	assert.Error(decoder.Decode(cadence.NewInt128(15), &i64))
+
+	// named types and nested values
+	type score uint32
+	var scores []score
+	err := decoder.Decode(cadence.NewArray([]cadence.Value{cadence.NewUInt32(1), cadence.NewUInt8(2)}), &scores)
+	assert.EqualError(err, "[1]: cannot convert UInt8 to godence.score")
+
+	// other types are not affected
+	var f float64
+	assert.NoError(decoder.Decode(cadence.Fix64(150000000), &f))
+	var s string
+	assert.ErrorIs(decoder.Decode(cadence.NewInt8(15), &s), ErrTypeMismatch)
+}
+
+func TestWithMapOrder(t *testing.T) {
+	assert := assert.New(t)
+	encoder := NewEncoder(WithMapOrder(MapOrderSorted))
+	value := map[string]uint8{"c": 3, "a": 1, "b": 2, "d": 4, "e": 5}
+	expect := cadence.NewDictionary([]cadence.KeyValuePair{
+		{Key: cadence.String("a"), Value: cadence.NewUInt8(1)},
+		{Key: cadence.String("b"), Value: cadence.NewUInt8(2)},
+		{Key: cadence.String("c"), Value: cadence.NewUInt8(3)},
+		{Key: cadence.String("d"), Value: cadence.NewUInt8(4)},
+		{Key: cadence.String("e"), Value: cadence.NewUInt8(5)},
+	})
+
+	for i := 0; i < 10; i++ {
+		cadenceValue, err := encoder.Encode(value)
+		assert.NoError(err)
+		assert.Equal(expect, cadenceValue)
+	}
+
+	typ := cadence.DictionaryType{KeyType: cadence.UInt64Type{}, ElementType: cadence.BoolType{}}
+	cadenceValue, err := encoder.EncodeAs(map[uint64]bool{2: false, 1: true, 3: true}, typ)
+	assert.NoError(err)
+	assert.Equal(cadence.NewDictionary([]cadence.KeyValuePair{
+		{Key: cadence.NewUInt64(1), Value: cadence.NewBool(true)},
+		{Key: cadence.NewUInt64(2), Value: cadence.NewBool(false)},
+		{Key: cadence.NewUInt64(3), Value: cadence.NewBool(true)},
+	}).WithType(typ), cadenceValue)
+}
+
+func TestWithConverterFuncs(t *testing.T) {
+	assert := assert.New(t)
+	encoder := NewEncoder(WithEncoderFunc(timeType, encodeTime))
+	decoder := NewDecoder(WithDecoderFunc(timeType, decodeTime))
+
+	cadenceValue, err := encoder.Encode(time.Unix(1, 0))
+	assert.NoError(err)
+	assert.Equal(cadence.UFix64(100000000), cadenceValue)
+
+	var dist time.Time
+	assert.NoError(decoder.Decode(cadenceValue, &dist))
+	assert.Equal(time.Unix(1, 0).UTC(), dist)
+
+	// registered later
+	decoder.RegisterDecoder(reflect.TypeOf(tokenAmount("")), func(value cadence.Value, dist any) error {
+		*dist.(*tokenAmount) = "0"
+		return nil
+	})
+	var amount tokenAmount
+	assert.NoError(decoder.Decode(cadenceValue, &amount))
+	assert.Equal(tokenAmount("0"), amount)
+}
@@ -0,0 +1,42 @@
+package godence
+
+import (
+	"github.com/onflow/cadence"
+)
+
+// Raw Keep the cadence value untouched to decode later, like json.RawMessage.
+// e.g. a field of AnyStruct type, decode it after checking the type ID.
+// A field of type cadence.Value works the same, Raw has helper methods.
+// Raw with nil value converts to cadence nil.
+type Raw struct {
+	Value cadence.Value
+}
+
+// MarshalCadence Implement CadenceMarshaler, return the value untouched.
+func (r Raw) MarshalCadence() (cadence.Value, error) {
+	if r.Value == nil {
+		return cadence.NewOptional(nil), nil
+	}
+	return r.Value, nil
+}
+
+// UnmarshalCadence Implement CadenceUnmarshaler, keep the value untouched, including optional and nil.
+func (r *Raw) UnmarshalCadence(value cadence.Value) error {
+	r.Value = value
+	return nil
+}
+
+// TypeID Type ID of the value, e.g. A.f8d6e0586b0a20c7.Market.Listing.
+// Empty if the value is nil.
+func (r Raw) TypeID() string {
+	if r.Value == nil {
+		return ""
+	}
+	return r.Value.Type().ID()
+}
+
+// Decode Convert the value to go by the default decoder, the same as ToGo.
+// Use Decoder.Decode(raw.Value, dist) for a configured decoder.
+func (r Raw) Decode(dist any) error {
+	return ToGo(r.Value, dist)
+}
@@ -0,0 +1,188 @@
+package godence
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+)
+
+// rawTestEvent. go struct keeps some fields untouched.
+type rawTestEvent struct {
+	IntValue    int64         `godence:"intValue"`
+	StringValue cadence.Value `godence:"stringValue"`
+	Address     Raw           `godence:"address"`
+}
+
+func TestRawField(t *testing.T) {
+	address := cadence.NewAddress([8]uint8{0xf8, 0xd6, 0xe0, 0x58, 0x6b, 0x0a, 0x20, 0xc7})
+
+	t.Run("script result", func(t *testing.T) {
+		assert := assert.New(t)
+		script := []byte(`
+		pub struct Cat {
+			pub let name: String
+			init() {
+				self.name = "LemonNeko"
+			}
+		}
+		pub struct Box {
+			pub let id: UInt64
+			pub let item: AnyStruct
+			init() {
+				self.id = 1
+				self.item = Cat()
+			}
+		}
+		pub fun main(): Box { return Box() }`)
+		type cat struct {
+			Name string `godence:"name"`
+		}
+		type box struct {
+			ID   uint64 `godence:"id"`
+			Item Raw    `godence:"item"`
+		}
+
+		ret, err := flowCli.ExecuteScriptAtLatestBlock(context.Background(), script, nil)
+		assert.NoError(err)
+
+		dist := box{}
+		assert.NoError(ToGo(ret, &dist))
+		assert.Equal(uint64(1), dist.ID)
+		assert.Equal("s.0000000000000000000000000000000000000000000000000000000000000000.Cat", dist.Item.TypeID())
+
+		item := cat{}
+		assert.NoError(dist.Item.Decode(&item))
+		assert.Equal("LemonNeko", item.Name)
+	})
+
+	t.Run("decode", func(t *testing.T) {
+		assert := assert.New(t)
+
+		dist := rawTestEvent{}
+		assert.NoError(ToGo(manyTypeEvent(), &dist))
+		assert.Equal(rawTestEvent{
+			IntValue:    -15,
+			StringValue: cadence.String("LemonNeko"),
+			Address:     Raw{Value: address},
+		}, dist)
+		assert.Equal("Address", dist.Address.TypeID())
+
+		str := ""
+		assert.NoError(ToGo(dist.StringValue, &str))
+		assert.Equal("LemonNeko", str)
+	})
+
+	t.Run("optional is untouched", func(t *testing.T) {
+		assert := assert.New(t)
+		none := cadence.NewOptional(nil)
+		some := cadence.NewOptional(cadence.String("LemonNeko"))
+
+		var value cadence.Value
+		assert.NoError(ToGo(none, &value))
+		assert.Equal(none, value)
+		assert.NoError(ToGo(some, &value))
+		assert.Equal(some, value)
+
+		raw := Raw{}
+		assert.NoError(ToGo(none, &raw))
+		assert.Equal(Raw{Value: none}, raw)
+		assert.Equal("Never?", raw.TypeID())
+	})
+
+	t.Run("elements", func(t *testing.T) {
+		assert := assert.New(t)
+		array := cadence.NewArray([]cadence.Value{cadence.String("LemonNeko"), cadence.NewUInt8(18)})
+
+		values := []cadence.Value{}
+		assert.NoError(ToGo(array, &values))
+		assert.Equal([]cadence.Value{cadence.String("LemonNeko"), cadence.NewUInt8(18)}, values)
+
+		raws := []Raw{}
+		assert.NoError(ToGo(array, &raws))
+		assert.Equal([]Raw{{Value: cadence.String("LemonNeko")}, {Value: cadence.NewUInt8(18)}}, raws)
+	})
+
+	t.Run("encode", func(t *testing.T) {
+		assert := assert.New(t)
+
+		value, err := ToCadence(cadence.String("LemonNeko"))
+		assert.NoError(err)
+		assert.Equal(cadence.String("LemonNeko"), value)
+
+		value, err = ToCadence(Raw{Value: address})
+		assert.NoError(err)
+		assert.Equal(address, value)
+
+		value, err = ToCadence(Raw{})
+		assert.NoError(err)
+		assert.Equal(cadence.NewOptional(nil), value)
+
+		value, err = ToCadence([]any{Raw{Value: cadence.NewUInt8(18)}, cadence.NewInt8(-1)})
+		assert.NoError(err)
+		assert.Equal(cadence.NewArray([]cadence.Value{cadence.NewUInt8(18), cadence.NewInt8(-1)}), value)
+	})
+
+	t.Run("encode as", func(t *testing.T) {
+		assert := assert.New(t)
+		expect := manyTypeEvent()
+		typ := &cadence.StructType{QualifiedIdentifier: expect.EventType.QualifiedIdentifier, Fields: expect.EventType.Fields}
+
+		// round trip, untouched values are checked by the field type
+		dist := rawTestEvent{}
+		assert.NoError(ToGo(expect, &dist))
+		value, err := ToCadenceAs(struct {
+			BoolValue   bool          `godence:"boolValue"`
+			IntValue    int64         `godence:"intValue"`
+			UIntValue   uint64        `godence:"uintValue"`
+			StringValue cadence.Value `godence:"stringValue"`
+			Address     Raw           `godence:"address"`
+		}{true, -15, 15, dist.StringValue, dist.Address}, typ)
+		assert.NoError(err)
+		assert.Equal(expect.Fields, value.(cadence.Struct).Fields)
+
+		_, err = ToCadenceAs(Raw{Value: address}, cadence.StringType{})
+		assert.ErrorIs(err, ErrTypeMismatch)
+	})
+
+	t.Run("AnyStruct round trip", func(t *testing.T) {
+		assert := assert.New(t)
+		type probe struct {
+			A Raw           `godence:"a"`
+			B cadence.Value `godence:"b"`
+			C Raw           `godence:"c"`
+			D Raw           `godence:"d"`
+		}
+		typ := &cadence.StructType{
+			QualifiedIdentifier: "Probe",
+			Fields: []cadence.Field{
+				{Identifier: "a", Type: cadence.OptionalType{Type: cadence.AnyStructType{}}},
+				{Identifier: "b", Type: cadence.AnyStructType{}},
+				{Identifier: "c", Type: cadence.OptionalType{Type: cadence.IntType{}}},
+				{Identifier: "d", Type: cadence.VariableSizedArrayType{ElementType: cadence.AnyStructType{}}},
+			},
+		}
+		expect := cadence.NewStruct([]cadence.Value{
+			cadence.NewOptional(cadence.NewInt(3)),
+			cadence.String("LemonNeko"),
+			cadence.NewOptional(nil),
+			cadence.NewArray([]cadence.Value{cadence.NewInt(1), cadence.String("LemonNeko")}).WithType(typ.Fields[3].Type.(cadence.ArrayType)),
+		}).WithType(typ)
+
+		dist := probe{}
+		assert.NoError(ToGo(expect, &dist))
+		cadenceValue, err := ToCadenceAs(dist, typ)
+		assert.NoError(err)
+		assert.Equal(expect, cadenceValue)
+
+		// not optional, nil value, untyped array
+		cadenceValue, err = ToCadenceAs(probe{
+			A: Raw{Value: cadence.NewInt(3)},
+			B: cadence.String("LemonNeko"),
+			D: Raw{Value: cadence.NewArray([]cadence.Value{cadence.NewInt(1), cadence.String("LemonNeko")})},
+		}, typ)
+		assert.NoError(err)
+		assert.Equal(expect, cadenceValue)
+	})
+}
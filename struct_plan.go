@@ -0,0 +1,162 @@
+package godence
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/onflow/cadence"
+)
+
+// fieldPlan. how to decode a go struct field.
+type fieldPlan struct {
+	// index of go field
+	goIndex int
+	// cadence field name, used in path of error
+	name string
+	// index of cadence field, -1 if cadence composite has no such field
+	cadenceIndex int
+	// options in godence tag
+	tag fieldTag
+}
+
+// structPlan. decode plan of a go struct type from a cadence composite type.
+type structPlan struct {
+	// cadence fields of the composite type, used to check if the plan can be reused
+	cadenceFields []string
+	fields        []fieldPlan
+	// indexes of cadence fields without go field
+	unmapped []int
+	// index of go field tagged remain, -1 if not any
+	remain int
+	// error of godence tags or ambiguous cadence fields
+	err error
+}
+
+// structPlanKey. go struct type, cadence composite type id and naming of fields.
+type structPlanKey struct {
+	goType reflect.Type
+	typeID string
+	naming fieldNaming
+}
+
+// structPlanKey -> *structPlan
+var structPlans sync.Map
+
+// compositeFieldsOf. type, fields and field values of cadence struct, event or resource.
+func compositeFieldsOf(value cadence.Value) (cadence.Type, []cadence.Field, []cadence.Value, bool) {
+	switch v := value.(type) {
+	case cadence.Struct:
+		return v.StructType, v.StructType.Fields, v.Fields, true
+	case cadence.Event:
+		return v.EventType, v.EventType.Fields, v.Fields, true
+	case cadence.Resource:
+		return v.ResourceType, v.ResourceType.Fields, v.Fields, true
+	}
+	return nil, nil, nil, false
+}
+
+// newStructPlan. match exported go fields with cadence fields by name under the naming strategy.
+func newStructPlan(goType reflect.Type, typ cadence.Type, fields []cadence.Field, naming fieldNaming) *structPlan {
+	plan := &structPlan{cadenceFields: make([]string, len(fields)), remain: -1}
+	// match key of cadence field name -> index
+	indexes, err := cadenceFieldIndexes(typ, fields, naming.strategy)
+	if err != nil {
+		plan.err = err
+		return plan
+	}
+	for i, field := range fields {
+		plan.cadenceFields[i] = field.Identifier
+	}
+	goFields, err := structFieldsOf(goType)
+	if err != nil {
+		plan.err = err
+		return plan
+	}
+	if _, err := goFieldKeys(goType, goFields, naming); err != nil {
+		plan.err = err
+		return plan
+	}
+	for _, goField := range goFields {
+		if goField.tag.remain {
+			plan.remain = goField.index
+			continue
+		}
+		name := goField.cadenceName(naming)
+		cadenceIndex, ok := indexes[naming.strategy.matchKey(name)]
+		if ok {
+			// the same as cadence, e.g. myName for MyName if case insensitive
+			name = fields[cadenceIndex].Identifier
+		} else {
+			cadenceIndex = -1
+		}
+		plan.fields = append(plan.fields, fieldPlan{goIndex: goField.index, name: name, cadenceIndex: cadenceIndex, tag: goField.tag})
+	}
+	mapped := make([]bool, len(fields))
+	for _, field := range plan.fields {
+		if field.cadenceIndex >= 0 {
+			mapped[field.cadenceIndex] = true
+		}
+	}
+	for i := range fields {
+		if !mapped[i] {
+			plan.unmapped = append(plan.unmapped, i)
+		}
+	}
+	return plan
+}
+
+// cadenceFieldIndexes. match key of cadence field name -> field index,
+// return AmbiguousFieldError if two fields have the same key.
+func cadenceFieldIndexes(typ cadence.Type, fields []cadence.Field, strategy NamingStrategy) (map[string]int, error) {
+	indexes := make(map[string]int, len(fields))
+	for i, field := range fields {
+		key := strategy.matchKey(field.Identifier)
+		if j, ok := indexes[key]; ok {
+			return nil, &AmbiguousFieldError{Fields: [2]string{fields[j].Identifier, field.Identifier}, CadenceType: typ}
+		}
+		indexes[key] = i
+	}
+	return indexes, nil
+}
+
+// goFieldKeys. match key of cadence field name -> go field, the remain field is excluded.
+// Return AmbiguousFieldError if two go fields have the same key, e.g. MyName and `json:"myName"`.
+func goFieldKeys(goType reflect.Type, fields []structField, naming fieldNaming) (map[string]structField, error) {
+	keys := make(map[string]structField, len(fields))
+	for _, field := range fields {
+		if field.tag.remain {
+			continue
+		}
+		key := naming.strategy.matchKey(field.cadenceName(naming))
+		if other, ok := keys[key]; ok {
+			return nil, &AmbiguousFieldError{Fields: [2]string{other.name, field.name}, GoType: goType, inGo: true}
+		}
+		keys[key] = field
+	}
+	return keys, nil
+}
+
+// matches. check if the plan is built from the same cadence fields.
+// Types without location, e.g. built by hand, may have the same type id but different fields.
+func (p *structPlan) matches(fields []cadence.Field) bool {
+	if len(p.cadenceFields) != len(fields) {
+		return false
+	}
+	for i, field := range fields {
+		if p.cadenceFields[i] != field.Identifier {
+			return false
+		}
+	}
+	return true
+}
+
+// structPlanOf. get cached decode plan, build it at the first time. Safe for concurrent use.
+func structPlanOf(goType reflect.Type, typ cadence.Type, fields []cadence.Field, naming fieldNaming) *structPlan {
+	key := structPlanKey{goType: goType, typeID: typ.ID(), naming: naming}
+	if cached, ok := structPlans.Load(key); ok && cached.(*structPlan).matches(fields) {
+		return cached.(*structPlan)
+	}
+	plan := newStructPlan(goType, typ, fields, naming)
+	structPlans.Store(key, plan)
+	return plan
+}
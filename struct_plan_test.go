@@ -0,0 +1,138 @@
+package godence
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+)
+
+// planTestEvent. go struct for manyTypeEvent.
+type planTestEvent struct {
+	IntValue    int64  `godence:"intValue"`
+	UIntValue   uint64 `godence:"uintValue"`
+	StringValue string `godence:"stringValue"`
+	BoolValue   bool   `godence:"boolValue"`
+	Address     string `godence:"address"`
+	unexported  string
+}
+
+// manyTypeEvent. cadence event with some fields, not in the same order as planTestEvent.
+func manyTypeEvent() cadence.Event {
+	return cadence.NewEvent([]cadence.Value{
+		cadence.NewBool(true),
+		cadence.NewInt64(-15),
+		cadence.NewUInt64(15),
+		cadence.String("LemonNeko"),
+		cadence.NewAddress([8]uint8{0xf8, 0xd6, 0xe0, 0x58, 0x6b, 0x0a, 0x20, 0xc7}),
+	}).WithType(&cadence.EventType{
+		QualifiedIdentifier: "PlanTest.ManyType",
+		Fields: []cadence.Field{
+			{Identifier: "boolValue", Type: cadence.BoolType{}},
+			{Identifier: "intValue", Type: cadence.Int64Type{}},
+			{Identifier: "uintValue", Type: cadence.UInt64Type{}},
+			{Identifier: "stringValue", Type: cadence.StringType{}},
+			{Identifier: "address", Type: cadence.AddressType{}},
+		},
+	})
+}
+
+func TestStructPlan(t *testing.T) {
+	expect := planTestEvent{
+		IntValue:    -15,
+		UIntValue:   15,
+		StringValue: "LemonNeko",
+		BoolValue:   true,
+		Address:     "0xf8d6e0586b0a20c7",
+	}
+
+	t.Run("plan is cached", func(t *testing.T) {
+		assert := assert.New(t)
+		// fields are not in the same order as go struct
+		value := manyTypeEvent()
+
+		dist := planTestEvent{}
+		assert.NoError(ToGo(value, &dist))
+		assert.Equal(expect, dist)
+
+		key := structPlanKey{goType: reflect.TypeOf(dist), typeID: "PlanTest.ManyType"}
+		cached, ok := structPlans.Load(key)
+		assert.True(ok)
+		plan := cached.(*structPlan)
+		assert.Len(plan.fields, 5)
+		assert.Equal(fieldPlan{goIndex: 0, name: "intValue", cadenceIndex: 1, tag: fieldTag{name: "intValue"}}, plan.fields[0])
+
+		// decode again with the cached plan
+		dist = planTestEvent{}
+		assert.NoError(ToGo(value, &dist))
+		assert.Equal(expect, dist)
+		cachedAgain, _ := structPlans.Load(key)
+		assert.Same(plan, cachedAgain)
+	})
+
+	t.Run("same type id, different fields", func(t *testing.T) {
+		type simpleStruct struct {
+			MyName string `godence:"myName"`
+		}
+		assert := assert.New(t)
+		build := func(fields []cadence.Field, values []cadence.Value) cadence.Value {
+			return cadence.NewStruct(values).WithType(&cadence.StructType{QualifiedIdentifier: "PlanTest.SimpleStruct", Fields: fields})
+		}
+
+		dist := simpleStruct{}
+		err := ToGo(build(
+			[]cadence.Field{{Identifier: "myName", Type: cadence.StringType{}}},
+			[]cadence.Value{cadence.String("LemonNeko")},
+		), &dist)
+		assert.NoError(err)
+		assert.Equal("LemonNeko", dist.MyName)
+
+		dist = simpleStruct{}
+		err = ToGo(build(
+			[]cadence.Field{{Identifier: "age", Type: cadence.UInt8Type{}}, {Identifier: "myName", Type: cadence.StringType{}}},
+			[]cadence.Value{cadence.NewUInt8(18), cadence.String("LemonNeko")},
+		), &dist)
+		assert.NoError(err)
+		assert.Equal("LemonNeko", dist.MyName)
+
+		err = ToGo(build(
+			[]cadence.Field{{Identifier: "age", Type: cadence.UInt8Type{}}},
+			[]cadence.Value{cadence.NewUInt8(18)},
+		), &dist)
+		assert.EqualError(err, "cannot find field named myName in PlanTest.SimpleStruct")
+	})
+
+	t.Run("concurrent use", func(t *testing.T) {
+		assert := assert.New(t)
+		value := manyTypeEvent()
+
+		results := make([]planTestEvent, 16)
+		errs := make([]error, 16)
+		wg := sync.WaitGroup{}
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = ToGo(value, &results[i])
+			}(i)
+		}
+		wg.Wait()
+		for i := range results {
+			assert.NoError(errs[i])
+			assert.Equal(expect, results[i])
+		}
+	})
+}
+
+func BenchmarkStructPlan(b *testing.B) {
+	value := manyTypeEvent()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dist := planTestEvent{}
+		if err := ToGo(value, &dist); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
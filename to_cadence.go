@@ -6,16 +6,12 @@ import (
 	"math/big"
 	"reflect"
 	"strings"
+	"sync"
 
 	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
 )
 
-// helper for ufix64
-type UFix64 uint64
-
-// helper for fix64
-type Fix64 int64
-
 // helper for Address
 type Address string
 
@@ -25,6 +21,75 @@ type Path string
 // helper for Character
 type Character string
 
+// registeredStruct. cadence type info of a registered go struct type.
+type registeredStruct struct {
+	location   common.Location
+	identifier string
+}
+
+// go struct type -> registeredStruct
+var registeredStructs sync.Map
+
+// RegisterStruct Register a go struct type with a fully qualified cadence type id,
+// e.g. A.f8d6e0586b0a20c7.ForTest.ForEmbedded.
+// After that, ToCadence can convert values of this type to cadence Struct.
+// Param 2 can be a struct value or a pointer to struct.
+func RegisterStruct(typeID string, value any) error {
+	t := reflect.TypeOf(value)
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return fmt.Errorf("register struct: not a struct type: %v", t)
+	}
+	location, identifier, err := common.DecodeTypeID(nil, typeID)
+	if err != nil {
+		return err
+	}
+	registeredStructs.Store(t, registeredStruct{location: location, identifier: identifier})
+	return nil
+}
+
+// go basic type of each kind, named types will convert to them, e.g. type Username string.
+var basicTypes = map[reflect.Kind]reflect.Type{
+	reflect.Int:     reflect.TypeOf(int(0)),
+	reflect.Int8:    reflect.TypeOf(int8(0)),
+	reflect.Int16:   reflect.TypeOf(int16(0)),
+	reflect.Int32:   reflect.TypeOf(int32(0)),
+	reflect.Int64:   reflect.TypeOf(int64(0)),
+	reflect.Uint:    reflect.TypeOf(uint(0)),
+	reflect.Uint8:   reflect.TypeOf(uint8(0)),
+	reflect.Uint16:  reflect.TypeOf(uint16(0)),
+	reflect.Uint32:  reflect.TypeOf(uint32(0)),
+	reflect.Uint64:  reflect.TypeOf(uint64(0)),
+	reflect.Float32: reflect.TypeOf(float32(0)),
+	reflect.Float64: reflect.TypeOf(float64(0)),
+	reflect.String:  reflect.TypeOf(""),
+	reflect.Bool:    reflect.TypeOf(false),
+}
+
+// helper types have special meaning, they will not convert to basic types.
+var helperTypes = map[reflect.Type]bool{
+	reflect.TypeOf(UFix64(0)):     true,
+	reflect.TypeOf(Fix64(0)):      true,
+	reflect.TypeOf(Address("")):   true,
+	reflect.TypeOf(Path("")):      true,
+	reflect.TypeOf(Character("")): true,
+}
+
+// toBasicValue. convert value of named type to go basic type of the same kind, return false if it is not a named type.
+func toBasicValue(value any) (any, bool) {
+	v := reflect.ValueOf(value)
+	if !v.IsValid() || helperTypes[v.Type()] {
+		return value, false
+	}
+	basic, ok := basicTypes[v.Kind()]
+	if !ok || v.Type() == basic {
+		return value, false
+	}
+	return v.Convert(basic).Interface(), true
+}
+
 // bigIntToCadence
 func bigIntToCadence(i *big.Int) (cadence.Value, error) {
 	// should from small to big
@@ -44,18 +109,23 @@ func bigIntToCadence(i *big.Int) (cadence.Value, error) {
 	if err == nil {
 		return u256, nil
 	}
-	return nil, fmt.Errorf("unsupport big.Int value: %s", i.Text(10))
+	// the largest type of the same sign
+	var typ cadence.Type = cadence.UInt256Type{}
+	if i.Sign() < 0 {
+		typ = cadence.Int256Type{}
+	}
+	return nil, &OverflowError{Value: i.Text(10), CadenceType: typ, GoType: reflect.TypeOf(i), toCadence: true}
 }
 
 // arrayOrSliceToCadence
-func arrayOrSliceToCadence(value any) (cadence.Value, error) {
+func (e *Encoder) arrayOrSliceToCadence(value any) (cadence.Value, error) {
 	ret := []cadence.Value{}
 	v := reflect.ValueOf(value)
 	for i := 0; i < v.Len(); i++ {
 		// convert all elements of slice/array
-		cv, err := ToCadence(v.Index(i).Interface())
+		cv, err := e.Encode(v.Index(i).Interface())
 		if err != nil {
-			return nil, err
+			return nil, withPath(err, fmt.Sprintf("[%d]", i))
 		}
 		ret = append(ret, cv)
 	}
@@ -63,18 +133,22 @@ func arrayOrSliceToCadence(value any) (cadence.Value, error) {
 }
 
 // mapToCadence
-func mapToCadence(value any) (cadence.Value, error) {
+func (e *Encoder) mapToCadence(value any) (cadence.Value, error) {
 	ret := []cadence.KeyValuePair{}
 	v := reflect.ValueOf(value)
+	keys := v.MapKeys()
+	if e.mapOrder == MapOrderSorted {
+		sortMapKeys(keys)
+	}
 	// convert all entry to KeyValuePair
-	for _, key := range v.MapKeys() {
-		ck, err := ToCadence(key.Interface())
+	for _, key := range keys {
+		ck, err := e.Encode(key.Interface())
 		if err != nil {
-			return nil, err
+			return nil, withPath(err, fmt.Sprintf("[key %#v]", key.Interface()))
 		}
-		cv, err := ToCadence(v.MapIndex(key).Interface())
+		cv, err := e.Encode(v.MapIndex(key).Interface())
 		if err != nil {
-			return nil, err
+			return nil, withPath(err, fmt.Sprintf("[%#v]", key.Interface()))
 		}
 		ret = append(ret, cadence.KeyValuePair{
 			Key:   ck,
@@ -84,11 +158,164 @@ func mapToCadence(value any) (cadence.Value, error) {
 	return cadence.NewDictionary(ret), nil
 }
 
-// ToCadence Convert any go value to cadence value.
-// Type uint64 will convert to UInt64, if you want to convert to UFix64,
-// you should use our UFix64 type.
+// structToCadence. the struct type must be registered by RegisterStruct.
+func (e *Encoder) structToCadence(v reflect.Value) (cadence.Value, error) {
+	registered, ok := registeredStructs.Load(v.Type())
+	if !ok {
+		return nil, &UnsupportedTypeError{GoType: v.Type(), unregistered: true}
+	}
+	info := registered.(registeredStruct)
+
+	goFields, err := structFieldsOf(v.Type())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := goFieldKeys(v.Type(), goFields, e.naming); err != nil {
+		return nil, err
+	}
+	fields := []cadence.Field{}
+	values := []cadence.Value{}
+	// cadence field name -> true
+	used := map[string]bool{}
+	var remain reflect.Value
+	// convert all exported fields, keep the order of go fields
+	for _, goField := range goFields {
+		if goField.tag.remain {
+			remain = v.Field(goField.index)
+			continue
+		}
+		name := goField.cadenceName(e.naming)
+		used[name] = true
+		cv, err := e.fieldToCadence(v.Field(goField.index), goField.tag, nil)
+		if err != nil {
+			return nil, withPath(err, name)
+		}
+		fieldType := cv.Type()
+		// nil has no inner type, use the type in tag
+		if goField.tag.typ != nil && isNilOptional(cv) {
+			fieldType = goField.tag.typ
+			if _, ok := fieldType.(cadence.OptionalType); !ok {
+				fieldType = cadence.OptionalType{Type: fieldType}
+			}
+		}
+		fields = append(fields, cadence.Field{
+			Identifier: name,
+			Type:       fieldType,
+		})
+		values = append(values, cv)
+	}
+	// then fields in remain field, sorted by name
+	if remain.IsValid() {
+		keys := remain.MapKeys()
+		sortMapKeys(keys)
+		for _, key := range keys {
+			name := key.String()
+			if used[name] {
+				continue
+			}
+			cv, err := e.remainToCadence(remain.MapIndex(key), nil)
+			if err != nil {
+				return nil, withPath(err, name)
+			}
+			fields = append(fields, cadence.Field{Identifier: name, Type: cv.Type()})
+			values = append(values, cv)
+		}
+	}
+	structType := cadence.NewStructType(info.location, info.identifier, fields, nil)
+	return cadence.NewStruct(values).WithType(structType), nil
+}
+
+// fieldToCadence. convert go struct field by options in godence tag, typ is type of cadence field, nil if unknown.
+// Empty value of omitempty field is cadence nil, others are cadence optional.
+func (e *Encoder) fieldToCadence(v reflect.Value, tag fieldTag, typ cadence.Type) (cadence.Value, error) {
+	if tag.omitEmpty && isEmptyValue(v) {
+		if _, ok := typ.(cadence.OptionalType); typ != nil && !ok {
+			return nil, mismatchToCadence(v.Interface(), typ)
+		}
+		return cadence.NewOptional(nil), nil
+	}
+	// the pointer is the optional, convert the pointed value
+	if tag.omitEmpty && v.Kind() == reflect.Pointer && v.Type() != reflect.TypeOf(&big.Int{}) {
+		v = v.Elem()
+	}
+	var cv cadence.Value
+	var err error
+	switch {
+	case tag.typ != nil:
+		cv, err = e.EncodeAs(v.Interface(), tag.typ)
+	case typ != nil:
+		return e.EncodeAs(v.Interface(), typ)
+	default:
+		cv, err = e.Encode(v.Interface())
+	}
+	if err != nil {
+		return nil, err
+	}
+	// check the type of cadence field
+	if typ != nil {
+		return e.EncodeAs(cv, typ)
+	}
+	if _, ok := cv.(cadence.Optional); tag.omitEmpty && !ok {
+		return cadence.NewOptional(cv), nil
+	}
+	return cv, nil
+}
+
+// remainToCadence. convert value in remain field, cadence.Value is not converted again, nil is cadence nil.
+// typ is type of cadence field, nil if unknown.
+func (e *Encoder) remainToCadence(v reflect.Value, typ cadence.Type) (cadence.Value, error) {
+	if typ != nil {
+		return e.EncodeAs(v.Interface(), typ)
+	}
+	if cv, ok := v.Interface().(cadence.Value); ok {
+		return cv, nil
+	}
+	// collected from cadence nil
+	if v.IsNil() {
+		return cadence.NewOptional(nil), nil
+	}
+	return e.Encode(v.Interface())
+}
+
+// parsePath. parse path like /storage/simpleR, domain should be storage, public or private.
+func parsePath(s string) (cadence.Path, bool) {
+	part := strings.Split(s, "/")
+	if len(part) != 3 || part[0] != "" || part[2] == "" || !isPathDomainOf(part[1], cadence.PathType{}) {
+		return cadence.Path{}, false
+	}
+	return cadence.NewPath(part[1], part[2]), true
+}
+
+// ToCadence Convert any go value to cadence value, by the default encoder.
+// See Encoder.Encode for the rules.
 func ToCadence(value any) (cadence.Value, error) {
+	return defaultEncoder.Encode(value)
+}
+
+// Encode Convert any go value to cadence value.
+// Type uint64 will convert to UInt64, if you want to convert to UFix64,
+// you should use our UFix64 type, it is scaled by 1e8.
+// Type float32 and float64 will convert to Fix64, use UFix64FromFloat for other rounding mode.
+// Go struct will convert to Struct, its type should be registered by RegisterStruct.
+// Pointer to go struct will convert to Struct, pointer to other types will convert to Optional, nil pointer is cadence nil.
+// Named types convert the same as their underlying kind, e.g. type Username string will convert to String.
+// Types registered by RegisterEncoder will convert by the registered function.
+// Types implement CadenceMarshaler will convert by MarshalCadence.
+// Cadence values and Raw are returned untouched.
+// Use errors.Is or errors.As to check the kind of error, see ErrUnsupportedType and others.
+func (e *Encoder) Encode(value any) (cadence.Value, error) {
+	// convert by the registered function
+	if fn, ok := e.encoderOf(value); ok {
+		return fn(value)
+	}
+	// convert by the type itself
+	if m, ok := marshalerOf(value); ok {
+		return m.MarshalCadence()
+	}
 	switch v := value.(type) {
+	// already a cadence value, e.g. a field of type cadence.Value
+	case cadence.Value:
+		return v, nil
 	// integer
 	case int:
 		return cadence.NewInt(v), nil
@@ -111,36 +338,77 @@ func ToCadence(value any) (cadence.Value, error) {
 	case uint64:
 		return cadence.NewUInt64(v), nil
 	case Fix64:
-		return cadence.NewFix64(fmt.Sprintf("%d.0", int64(v)))
+		return cadence.Fix64(v), nil
 	case UFix64:
-		return cadence.NewUFix64(fmt.Sprintf("%d.0", uint64(v)))
+		return cadence.UFix64(v), nil
 	case *big.Int:
+		if v == nil {
+			return cadence.NewOptional(nil), nil
+		}
 		return bigIntToCadence(v)
-	// TODO: float, should float convert to Fix64?
-	// case float32:
-	// case float64:
+	// float will convert to Fix64, rounded half to even
+	case float32:
+		f, err := fix64FromFloat(float64(v), 32, RoundHalfEven)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.Fix64(f), nil
+	case float64:
+		f, err := fix64FromFloat(v, 64, RoundHalfEven)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.Fix64(f), nil
 	case string:
 		return cadence.NewString(v)
 	case Address:
 		decoded, err := hex.DecodeString(strings.TrimPrefix(string(v), "0x"))
 		return cadence.BytesToAddress(decoded), err
 	case Path:
-		part := strings.Split(string(v), "/")
-		return cadence.NewPath(part[1], part[2]), nil
+		path, ok := parsePath(string(v))
+		if !ok {
+			return nil, fmt.Errorf("invalid path %q: %w", string(v), mismatchToCadence(v, cadence.PathType{}))
+		}
+		return path, nil
 	case Character:
 		return cadence.NewCharacter(string(v))
 	case bool:
 		return cadence.NewBool(v), nil
 	}
+	if value == nil {
+		return nil, &UnsupportedTypeError{}
+	}
+	// named types, e.g. type Score uint32 will convert to UInt32
+	if basic, ok := toBasicValue(value); ok {
+		return e.Encode(basic)
+	}
 	switch reflect.TypeOf(value).Kind() {
 	// array or slice
 	case reflect.Slice, reflect.Array:
-		return arrayOrSliceToCadence(value)
+		return e.arrayOrSliceToCadence(value)
 	// map
 	case reflect.Map:
-		return mapToCadence(value)
+		return e.mapToCadence(value)
+	// struct or pointer to struct
+	case reflect.Struct:
+		return e.structToCadence(reflect.ValueOf(value))
+	case reflect.Pointer:
+		v := reflect.ValueOf(value)
+		// nil pointer is cadence nil, the same as decoding
+		if v.IsNil() {
+			return cadence.NewOptional(nil), nil
+		}
+		if v.Type().Elem().Kind() == reflect.Struct {
+			return e.structToCadence(v.Elem())
+		}
+		// pointer to other types is optional
+		cv, err := e.Encode(v.Elem().Interface())
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewOptional(cv), nil
 	}
-	return nil, fmt.Errorf("unsupport type: %s", reflect.TypeOf(value))
+	return nil, &UnsupportedTypeError{GoType: reflect.TypeOf(value)}
 }
 
 // ToCadence Convert any go value to cadence optional value.
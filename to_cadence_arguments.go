@@ -0,0 +1,167 @@
+package godence
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/parser2"
+)
+
+// cadence built-in type name -> cadence type
+var builtinTypes = map[string]cadence.Type{
+	"Int":            cadence.IntType{},
+	"Int8":           cadence.Int8Type{},
+	"Int16":          cadence.Int16Type{},
+	"Int32":          cadence.Int32Type{},
+	"Int64":          cadence.Int64Type{},
+	"Int128":         cadence.Int128Type{},
+	"Int256":         cadence.Int256Type{},
+	"UInt":           cadence.UIntType{},
+	"UInt8":          cadence.UInt8Type{},
+	"UInt16":         cadence.UInt16Type{},
+	"UInt32":         cadence.UInt32Type{},
+	"UInt64":         cadence.UInt64Type{},
+	"UInt128":        cadence.UInt128Type{},
+	"UInt256":        cadence.UInt256Type{},
+	"Word8":          cadence.Word8Type{},
+	"Word16":         cadence.Word16Type{},
+	"Word32":         cadence.Word32Type{},
+	"Word64":         cadence.Word64Type{},
+	"Fix64":          cadence.Fix64Type{},
+	"UFix64":         cadence.UFix64Type{},
+	"String":         cadence.StringType{},
+	"Character":      cadence.CharacterType{},
+	"Bool":           cadence.BoolType{},
+	"Address":        cadence.AddressType{},
+	"Path":           cadence.PathType{},
+	"StoragePath":    cadence.StoragePathType{},
+	"PublicPath":     cadence.PublicPathType{},
+	"PrivatePath":    cadence.PrivatePathType{},
+	"CapabilityPath": cadence.CapabilityPathType{},
+	"AnyStruct":      cadence.AnyStructType{},
+	"Any":            cadence.AnyType{},
+}
+
+// astTypeToCadence. convert type in cadence source to cadence type.
+// Composite types are resolved with imports, their fields are unknown.
+func astTypeToCadence(typ ast.Type, imports []*ast.ImportDeclaration) (cadence.Type, error) {
+	switch t := typ.(type) {
+	case *ast.NominalType:
+		if len(t.NestedIdentifiers) == 0 {
+			if builtin, ok := builtinTypes[t.Identifier.Identifier]; ok {
+				return builtin, nil
+			}
+		}
+		return nominalTypeToCadence(t, imports), nil
+	case *ast.OptionalType:
+		inner, err := astTypeToCadence(t.Type, imports)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.OptionalType{Type: inner}, nil
+	case *ast.VariableSizedType:
+		element, err := astTypeToCadence(t.Type, imports)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.VariableSizedArrayType{ElementType: element}, nil
+	case *ast.ConstantSizedType:
+		element, err := astTypeToCadence(t.Type, imports)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.ConstantSizedArrayType{Size: uint(t.Size.Value.Uint64()), ElementType: element}, nil
+	case *ast.DictionaryType:
+		key, err := astTypeToCadence(t.KeyType, imports)
+		if err != nil {
+			return nil, err
+		}
+		value, err := astTypeToCadence(t.ValueType, imports)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.DictionaryType{KeyType: key, ElementType: value}, nil
+	case *ast.RestrictedType:
+		// restricted type like AnyStruct{I}, accept any value
+		return cadence.AnyStructType{}, nil
+	}
+	return nil, fmt.Errorf("unsupport parameter type: %s", typ)
+}
+
+// nominalTypeToCadence. find location of composite type from imports.
+func nominalTypeToCadence(typ *ast.NominalType, imports []*ast.ImportDeclaration) cadence.Type {
+	identifiers := []string{typ.Identifier.Identifier}
+	for _, nested := range typ.NestedIdentifiers {
+		identifiers = append(identifiers, nested.Identifier)
+	}
+	qualifiedIdentifier := strings.Join(identifiers, ".")
+
+	var location common.Location
+	for _, declaration := range imports {
+		for _, imported := range declaration.Identifiers {
+			if imported.Identifier != typ.Identifier.Identifier {
+				continue
+			}
+			location = declaration.Location
+			// contract name is the first part of qualified identifier
+			if addressLocation, ok := location.(common.AddressLocation); ok {
+				location = common.AddressLocation{Address: addressLocation.Address, Name: imported.Identifier}
+			}
+		}
+	}
+	return &cadence.StructType{Location: location, QualifiedIdentifier: qualifiedIdentifier}
+}
+
+// scriptParameters. get parameters of transaction or main function in script.
+func scriptParameters(script []byte) ([]*ast.Parameter, []*ast.ImportDeclaration, error) {
+	program, err := parser2.ParseProgram(string(script), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if transaction := program.SoleTransactionDeclaration(); transaction != nil {
+		if transaction.ParameterList == nil {
+			return nil, program.ImportDeclarations(), nil
+		}
+		return transaction.ParameterList.Parameters, program.ImportDeclarations(), nil
+	}
+	for _, function := range program.FunctionDeclarations() {
+		if function.Identifier.Identifier == "main" {
+			return function.ParameterList.Parameters, program.ImportDeclarations(), nil
+		}
+	}
+	return nil, nil, fmt.Errorf("cannot find transaction or main function in script")
+}
+
+// ToCadenceArguments Convert go values to cadence arguments by parameter types declared in
+// transaction(...) or pub fun main(...) of the script. The result can be passed to AddArgument.
+// Composite parameters should be registered by RegisterStruct.
+func ToCadenceArguments(script []byte, values ...any) ([]cadence.Value, error) {
+	return defaultEncoder.EncodeArguments(script, values...)
+}
+
+// EncodeArguments The same as ToCadenceArguments, values are converted by the encoder.
+func (e *Encoder) EncodeArguments(script []byte, values ...any) ([]cadence.Value, error) {
+	parameters, imports, err := scriptParameters(script)
+	if err != nil {
+		return nil, err
+	}
+	if len(parameters) != len(values) {
+		return nil, fmt.Errorf("script requires %d arguments, but got %d", len(parameters), len(values))
+	}
+	ret := []cadence.Value{}
+	for index, parameter := range parameters {
+		typ, err := astTypeToCadence(parameter.TypeAnnotation.Type, imports)
+		if err != nil {
+			return nil, err
+		}
+		cv, err := e.EncodeAs(values[index], typ)
+		if err != nil {
+			return nil, fmt.Errorf("argument %s: %w", parameter.Identifier.Identifier, err)
+		}
+		ret = append(ret, cv)
+	}
+	return ret, nil
+}
@@ -0,0 +1,142 @@
+package godence
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToCadenceArguments(t *testing.T) {
+	type forEmbedded struct {
+		MyName string `godence:"myName"`
+	}
+
+	t.Run("script", func(t *testing.T) {
+		assert := assert.New(t)
+		script := []byte(`
+pub fun main(a: UFix64, b: Address, c: [UInt256], d: {String: Int32}?, e: StoragePath): UFix64 {
+	return a
+}`)
+
+		args, err := ToCadenceArguments(script, 15.0, "0xf8d6e0586b0a20c7", []*big.Int{big.NewInt(1)}, map[string]int{"LemonNeko": 1}, "/storage/simpleR")
+		assert.NoError(err)
+		assert.Len(args, 5)
+		assert.Equal("UFix64", args[0].Type().ID())
+		assert.Equal("Address", args[1].Type().ID())
+		assert.Equal("[UInt256]", args[2].Type().ID())
+		assert.Equal("{String:Int32}?", args[3].Type().ID())
+		assert.Equal("Path", args[4].Type().ID())
+
+		ret, err := flowCli.ExecuteScriptAtLatestBlock(context.Background(), script, args)
+		assert.NoError(err)
+		assert.Equal(cadence.UFix64(1500000000), ret)
+	})
+
+	t.Run("transaction", func(t *testing.T) {
+		assert := assert.New(t)
+		script := []byte(`
+transaction(name: String, age: UInt8?) {
+	prepare(signer: AuthAccount) {}
+}`)
+
+		args, err := ToCadenceArguments(script, "LemonNeko", nil)
+		assert.NoError(err)
+		assert.Equal([]cadence.Value{cadence.String("LemonNeko"), cadence.NewOptional(nil)}, args)
+	})
+
+	t.Run("AnyStruct", func(t *testing.T) {
+		assert := assert.New(t)
+		script := []byte(`
+pub fun main(a: AnyStruct, b: AnyStruct?, c: [Int]): AnyStruct {
+	return a
+}`)
+
+		args, err := ToCadenceArguments(script, cadence.NewInt(1), cadence.NewOptional(nil), cadence.NewArray([]cadence.Value{cadence.NewInt(1)}))
+		assert.NoError(err)
+		assert.Equal([]cadence.Value{
+			cadence.NewInt(1),
+			cadence.NewOptional(nil),
+			cadence.NewArray([]cadence.Value{cadence.NewInt(1)}).WithType(cadence.VariableSizedArrayType{ElementType: cadence.IntType{}}),
+		}, args)
+	})
+
+	t.Run("transaction without parameter", func(t *testing.T) {
+		assert := assert.New(t)
+		script := []byte(`
+transaction {
+	prepare(signer: AuthAccount) {}
+}`)
+
+		args, err := ToCadenceArguments(script)
+		assert.NoError(err)
+		assert.Empty(args)
+	})
+
+	t.Run("imported struct", func(t *testing.T) {
+		assert := assert.New(t)
+		err := RegisterStruct("A.f8d6e0586b0a20c7.ForTest.ForEmbedded", forEmbedded{})
+		assert.NoError(err)
+		script := []byte(`
+import ForTest from 0xf8d6e0586b0a20c7
+
+pub fun main(arg: ForTest.ForEmbedded): String {
+	return arg.myName
+}`)
+
+		args, err := ToCadenceArguments(script, forEmbedded{MyName: "LemonNeko"})
+		assert.NoError(err)
+		assert.Equal("A.f8d6e0586b0a20c7.ForTest.ForEmbedded", args[0].Type().ID())
+
+		ret, err := flowCli.ExecuteScriptAtLatestBlock(context.Background(), script, args)
+		assert.NoError(err)
+		assert.Equal(cadence.String("LemonNeko"), ret)
+	})
+
+	t.Run("struct type mismatched", func(t *testing.T) {
+		assert := assert.New(t)
+		err := RegisterStruct("A.f8d6e0586b0a20c7.ForTest.ForEmbedded", forEmbedded{})
+		assert.NoError(err)
+		script := []byte(`
+import ForTest from 0x01cf0e2f2f715450
+
+pub fun main(arg: ForTest.ForEmbedded) {}`)
+
+		_, err = ToCadenceArguments(script, forEmbedded{MyName: "LemonNeko"})
+		assert.EqualError(err, "argument arg: cannot convert godence.forEmbedded to A.01cf0e2f2f715450.ForTest.ForEmbedded")
+	})
+
+	t.Run("wrong argument count", func(t *testing.T) {
+		assert := assert.New(t)
+		script := []byte(`pub fun main(a: Int, b: Int) {}`)
+
+		_, err := ToCadenceArguments(script, 1)
+		assert.EqualError(err, "script requires 2 arguments, but got 1")
+	})
+
+	t.Run("wrong argument type", func(t *testing.T) {
+		assert := assert.New(t)
+		script := []byte(`pub fun main(a: UInt8) {}`)
+
+		_, err := ToCadenceArguments(script, 256)
+		assert.EqualError(err, "argument a: value 256 out of range of UInt8")
+	})
+
+	t.Run("no entry point", func(t *testing.T) {
+		assert := assert.New(t)
+		script := []byte(`pub fun notMain() {}`)
+
+		_, err := ToCadenceArguments(script)
+		assert.EqualError(err, "cannot find transaction or main function in script")
+	})
+
+	t.Run("syntax error", func(t *testing.T) {
+		assert := assert.New(t)
+		script := []byte(`pub fun main(a: ) {}`)
+
+		_, err := ToCadenceArguments(script)
+		assert.Error(err)
+	})
+}
@@ -0,0 +1,513 @@
+package godence
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/onflow/cadence"
+)
+
+// intRange. value range of a cadence integer type, nil means unbounded.
+type intRange struct {
+	min *big.Int
+	max *big.Int
+}
+
+// signedRange. range of signed integer with bit size.
+func signedRange(bits uint) intRange {
+	max := new(big.Int).Lsh(big.NewInt(1), bits-1)
+	min := new(big.Int).Neg(max)
+	return intRange{min: min, max: max.Sub(max, big.NewInt(1))}
+}
+
+// unsignedRange. range of unsigned integer with bit size.
+func unsignedRange(bits uint) intRange {
+	max := new(big.Int).Lsh(big.NewInt(1), bits)
+	return intRange{min: big.NewInt(0), max: max.Sub(max, big.NewInt(1))}
+}
+
+// cadence integer type id -> value range
+var integerRanges = map[string]intRange{
+	"Int":     {},
+	"Int8":    signedRange(8),
+	"Int16":   signedRange(16),
+	"Int32":   signedRange(32),
+	"Int64":   signedRange(64),
+	"Int128":  signedRange(128),
+	"Int256":  signedRange(256),
+	"UInt":    {min: big.NewInt(0)},
+	"UInt8":   unsignedRange(8),
+	"UInt16":  unsignedRange(16),
+	"UInt32":  unsignedRange(32),
+	"UInt64":  unsignedRange(64),
+	"UInt128": unsignedRange(128),
+	"UInt256": unsignedRange(256),
+	"Word8":   unsignedRange(8),
+	"Word16":  unsignedRange(16),
+	"Word32":  unsignedRange(32),
+	"Word64":  unsignedRange(64),
+}
+
+// contains. check if i is in range.
+func (r intRange) contains(i *big.Int) bool {
+	if r.min != nil && i.Cmp(r.min) < 0 {
+		return false
+	}
+	if r.max != nil && i.Cmp(r.max) > 0 {
+		return false
+	}
+	return true
+}
+
+// goIntegerToBig. convert any go integer to big.Int, return false if value is not an integer.
+func goIntegerToBig(value any) (*big.Int, bool) {
+	if i, ok := value.(*big.Int); ok {
+		if i == nil {
+			return nil, false
+		}
+		return new(big.Int).Set(i), true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Int).SetUint64(v.Uint()), true
+	}
+	return nil, false
+}
+
+// bigToCadenceInteger. build cadence integer value of type id, i must be in range.
+func bigToCadenceInteger(i *big.Int, typeID string) (cadence.Value, error) {
+	switch typeID {
+	case "Int":
+		return cadence.NewIntFromBig(i), nil
+	case "Int8":
+		return cadence.NewInt8(int8(i.Int64())), nil
+	case "Int16":
+		return cadence.NewInt16(int16(i.Int64())), nil
+	case "Int32":
+		return cadence.NewInt32(int32(i.Int64())), nil
+	case "Int64":
+		return cadence.NewInt64(i.Int64()), nil
+	case "Int128":
+		return cadence.NewInt128FromBig(i)
+	case "Int256":
+		return cadence.NewInt256FromBig(i)
+	case "UInt":
+		return cadence.NewUIntFromBig(i)
+	case "UInt8":
+		return cadence.NewUInt8(uint8(i.Uint64())), nil
+	case "UInt16":
+		return cadence.NewUInt16(uint16(i.Uint64())), nil
+	case "UInt32":
+		return cadence.NewUInt32(uint32(i.Uint64())), nil
+	case "UInt64":
+		return cadence.NewUInt64(i.Uint64()), nil
+	case "UInt128":
+		return cadence.NewUInt128FromBig(i)
+	case "UInt256":
+		return cadence.NewUInt256FromBig(i)
+	case "Word8":
+		return cadence.NewWord8(uint8(i.Uint64())), nil
+	case "Word16":
+		return cadence.NewWord16(uint16(i.Uint64())), nil
+	case "Word32":
+		return cadence.NewWord32(uint32(i.Uint64())), nil
+	case "Word64":
+		return cadence.NewWord64(i.Uint64()), nil
+	}
+	return nil, fmt.Errorf("unsupport integer type: %s", typeID)
+}
+
+// toCadenceIntegerAs. convert go integer to cadence integer type with range checking.
+func toCadenceIntegerAs(value any, typ cadence.Type) (cadence.Value, error) {
+	i, ok := goIntegerToBig(value)
+	if !ok {
+		return nil, mismatchToCadence(value, typ)
+	}
+	if !integerRanges[typ.ID()].contains(i) {
+		return nil, &OverflowError{Value: i.Text(10), CadenceType: typ, GoType: reflect.TypeOf(value), toCadence: true}
+	}
+	return bigToCadenceInteger(i, typ.ID())
+}
+
+// toCadenceFixedPointAs. convert go float or fixed-point helper to Fix64 or UFix64 with range checking.
+func toCadenceFixedPointAs(value any, typ cadence.Type) (cadence.Value, error) {
+	var raw *big.Int
+	// named types, e.g. type Price float64
+	basic, _ := toBasicValue(value)
+	switch v := basic.(type) {
+	// Fix64 and UFix64 can convert to each other, if in range
+	case Fix64:
+		raw = big.NewInt(int64(v))
+	case UFix64:
+		raw = new(big.Int).SetUint64(uint64(v))
+	// float is rounded half to even
+	case float32:
+		f, err := floatToFixedPoint(float64(v), 32, RoundHalfEven, typ)
+		if err != nil {
+			return nil, err
+		}
+		raw = f
+	case float64:
+		f, err := floatToFixedPoint(v, 64, RoundHalfEven, typ)
+		if err != nil {
+			return nil, err
+		}
+		raw = f
+	default:
+		// go integers are not accepted, they are raw values when decoding but whole numbers to the reader,
+		// use Fix64, UFix64 or float instead
+		return nil, mismatchToCadence(value, typ)
+	}
+	if _, ok := typ.(cadence.Fix64Type); ok {
+		if !signedRange(64).contains(raw) {
+			return nil, &OverflowError{Value: formatFixedPoint(raw), CadenceType: typ, GoType: reflect.TypeOf(value), toCadence: true}
+		}
+		return cadence.Fix64(raw.Int64()), nil
+	}
+	if !unsignedRange(64).contains(raw) {
+		return nil, &OverflowError{Value: formatFixedPoint(raw), CadenceType: typ, GoType: reflect.TypeOf(value), toCadence: true}
+	}
+	return cadence.UFix64(raw.Uint64()), nil
+}
+
+// formatFixedPoint. format a 1e8 scaled value for error message.
+func formatFixedPoint(raw *big.Int) string {
+	whole, fraction := new(big.Int).QuoRem(raw, big.NewInt(fix64Factor), new(big.Int))
+	sign := ""
+	if raw.Sign() < 0 {
+		sign = "-"
+		whole.Neg(whole)
+		fraction.Neg(fraction)
+	}
+	return fmt.Sprintf("%s%s.%08d", sign, whole.Text(10), fraction.Int64())
+}
+
+// toCadenceArrayAs. convert go slice or array to cadence array type.
+func (e *Encoder) toCadenceArrayAs(value any, typ cadence.ArrayType) (cadence.Value, error) {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, mismatchToCadence(value, typ)
+	}
+	if constant, ok := typ.(cadence.ConstantSizedArrayType); ok && uint(v.Len()) != constant.Size {
+		return nil, fmt.Errorf("length %d: %w", v.Len(), mismatchToCadence(value, typ))
+	}
+	ret := []cadence.Value{}
+	for i := 0; i < v.Len(); i++ {
+		cv, err := e.EncodeAs(v.Index(i).Interface(), typ.Element())
+		if err != nil {
+			return nil, withPath(err, fmt.Sprintf("[%d]", i))
+		}
+		ret = append(ret, cv)
+	}
+	return cadence.NewArray(ret).WithType(typ), nil
+}
+
+// toCadenceDictionaryAs. convert go map to cadence dictionary type.
+func (e *Encoder) toCadenceDictionaryAs(value any, typ cadence.DictionaryType) (cadence.Value, error) {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Map {
+		return nil, mismatchToCadence(value, typ)
+	}
+	keys := v.MapKeys()
+	if e.mapOrder == MapOrderSorted {
+		sortMapKeys(keys)
+	}
+	ret := []cadence.KeyValuePair{}
+	for _, key := range keys {
+		ck, err := e.EncodeAs(key.Interface(), typ.KeyType)
+		if err != nil {
+			return nil, withPath(err, fmt.Sprintf("[key %#v]", key.Interface()))
+		}
+		cv, err := e.EncodeAs(v.MapIndex(key).Interface(), typ.ElementType)
+		if err != nil {
+			return nil, withPath(err, fmt.Sprintf("[%#v]", key.Interface()))
+		}
+		ret = append(ret, cadence.KeyValuePair{Key: ck, Value: cv})
+	}
+	return cadence.NewDictionary(ret).WithType(typ), nil
+}
+
+// toCadenceStructAs. convert go struct to the given cadence struct type, fields are matched by name.
+// If fields of struct type are unknown, the go struct type should be registered by RegisterStruct.
+func (e *Encoder) toCadenceStructAs(value any, typ *cadence.StructType) (cadence.Value, error) {
+	if len(typ.Fields) == 0 {
+		cv, err := e.Encode(value)
+		if err != nil {
+			return nil, err
+		}
+		// without location, only qualified identifier can be compared
+		if s, ok := cv.(cadence.Struct); ok && typ.Location == nil && s.StructType.QualifiedIdentifier == typ.QualifiedIdentifier {
+			return cv, nil
+		}
+		if !isCadenceTypeCompatible(cv.Type(), typ) {
+			return nil, mismatchToCadence(value, typ)
+		}
+		return cv, nil
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, mismatchToCadence(value, typ)
+	}
+	structFields, err := structFieldsOf(v.Type())
+	if err != nil {
+		return nil, err
+	}
+	// match key of cadence field name -> cadence field index
+	cadenceFields, err := cadenceFieldIndexes(typ, typ.Fields, e.naming.strategy)
+	if err != nil {
+		return nil, err
+	}
+	// match key of cadence field name -> go field
+	goFields, err := goFieldKeys(v.Type(), structFields, e.naming)
+	if err != nil {
+		return nil, err
+	}
+	var remain reflect.Value
+	for _, goField := range structFields {
+		if goField.tag.remain {
+			remain = v.Field(goField.index)
+		}
+	}
+	// go fields and fields in remain field without cadence field, optional fields are allowed
+	if e.strict {
+		if remain.IsValid() {
+			keys := remain.MapKeys()
+			sortMapKeys(keys)
+			for _, key := range keys {
+				if _, ok := cadenceFields[e.naming.strategy.matchKey(key.String())]; !ok {
+					return nil, &FieldNotFoundError{Field: key.String(), CadenceType: typ, GoType: v.Type()}
+				}
+			}
+		}
+		for _, goField := range structFields {
+			if goField.tag.remain {
+				continue
+			}
+			name := goField.cadenceName(e.naming)
+			if _, ok := cadenceFields[e.naming.strategy.matchKey(name)]; !ok && !goField.tag.optional {
+				return nil, &FieldNotFoundError{Field: name, CadenceType: typ, GoType: v.Type()}
+			}
+		}
+	}
+	values := []cadence.Value{}
+	for _, field := range typ.Fields {
+		goField, ok := goFields[e.naming.strategy.matchKey(field.Identifier)]
+		if !ok {
+			// from remain field
+			if entry := remainEntryOf(remain, field.Identifier); entry.IsValid() {
+				cv, err := e.remainToCadence(entry, field.Type)
+				if err != nil {
+					return nil, withPath(err, field.Identifier)
+				}
+				values = append(values, cv)
+				continue
+			}
+			return nil, &FieldNotFoundError{Field: field.Identifier, CadenceType: typ, GoType: v.Type(), missingInGo: true}
+		}
+		cv, err := e.fieldToCadence(v.Field(goField.index), goField.tag, field.Type)
+		if err != nil {
+			return nil, withPath(err, field.Identifier)
+		}
+		values = append(values, cv)
+	}
+	return cadence.NewStruct(values).WithType(typ), nil
+}
+
+// remainEntryOf. value of name in remain field, invalid if remain field or the entry does not exist.
+func remainEntryOf(remain reflect.Value, name string) reflect.Value {
+	if !remain.IsValid() || remain.IsNil() {
+		return reflect.Value{}
+	}
+	return remain.MapIndex(reflect.ValueOf(name).Convert(remain.Type().Key()))
+}
+
+// ToCadenceAs Convert go value to cadence value of the expected type, by the default encoder.
+// See Encoder.EncodeAs for the rules.
+func ToCadenceAs(value any, typ cadence.Type) (cadence.Value, error) {
+	return defaultEncoder.EncodeAs(value, typ)
+}
+
+// EncodeAs Convert go value to cadence value of the expected type.
+// Integers will be range checked, arrays, dictionaries, optionals and structs will be converted recursively.
+// e.g. EncodeAs(big.NewInt(1), cadence.UInt256Type{}) will get UInt256 rather than Int128.
+// Cadence values are checked by type, untyped arrays and dictionaries are checked element by element.
+func (e *Encoder) EncodeAs(value any, typ cadence.Type) (cadence.Value, error) {
+	if typ == nil {
+		return nil, &UnsupportedTypeError{GoType: reflect.TypeOf(value), nilType: true}
+	}
+	// convert by the registered function, then check the type
+	if fn, ok := e.encoderOf(value); ok {
+		cv, err := fn(value)
+		if err != nil {
+			return nil, err
+		}
+		return e.EncodeAs(cv, typ)
+	}
+	// convert by the type itself, then check the type
+	if m, ok := marshalerOf(value); ok {
+		cv, err := m.MarshalCadence()
+		if err != nil {
+			return nil, err
+		}
+		return e.EncodeAs(cv, typ)
+	}
+	// already a cadence value, check type only
+	if cv, ok := value.(cadence.Value); ok {
+		return e.cadenceValueAs(cv, typ)
+	}
+	switch t := typ.(type) {
+	case cadence.OptionalType:
+		v := reflect.ValueOf(value)
+		// nil or nil pointer is cadence nil
+		if value == nil || (v.Kind() == reflect.Pointer && v.IsNil()) {
+			return cadence.NewOptional(nil), nil
+		}
+		if _, isBig := value.(*big.Int); v.Kind() == reflect.Pointer && !isBig {
+			value = v.Elem().Interface()
+		}
+		cv, err := e.EncodeAs(value, t.Type)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewOptional(cv), nil
+	case cadence.ArrayType:
+		return e.toCadenceArrayAs(value, t)
+	case cadence.DictionaryType:
+		return e.toCadenceDictionaryAs(value, t)
+	case *cadence.StructType:
+		return e.toCadenceStructAs(value, t)
+	case cadence.Fix64Type, cadence.UFix64Type:
+		return toCadenceFixedPointAs(value, typ)
+	case cadence.AnyType, cadence.AnyStructType:
+		return e.Encode(value)
+	}
+	if _, ok := integerRanges[typ.ID()]; ok {
+		return toCadenceIntegerAs(value, typ)
+	}
+	// other types, convert by go type and check the result
+	var cv cadence.Value
+	var err error
+	// named types, e.g. type Username string can convert to Address
+	basic, _ := toBasicValue(value)
+	switch v := basic.(type) {
+	case string:
+		switch typ.(type) {
+		case cadence.AddressType:
+			cv, err = e.Encode(Address(v))
+		case cadence.PathType, cadence.StoragePathType, cadence.PublicPathType, cadence.PrivatePathType, cadence.CapabilityPathType:
+			cv, err = e.Encode(Path(v))
+		case cadence.CharacterType:
+			cv, err = e.Encode(Character(v))
+		default:
+			cv, err = e.Encode(v)
+		}
+	case [8]uint8:
+		cv = cadence.NewAddress(v)
+	default:
+		cv, err = e.Encode(value)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !isCadenceValueCompatible(cv, typ) {
+		return nil, mismatchToCadence(value, typ)
+	}
+	return cv, nil
+}
+
+// cadenceValueAs. check if cadence value can be used as the expected type.
+// Any value can be used as AnyStruct or Any, nil can be used as any optional type.
+// Untyped arrays and dictionaries, e.g. converted by Encode, are checked element by element and get the expected type.
+func (e *Encoder) cadenceValueAs(value cadence.Value, typ cadence.Type) (cadence.Value, error) {
+	switch t := typ.(type) {
+	case cadence.AnyType, cadence.AnyStructType:
+		return value, nil
+	case cadence.OptionalType:
+		optional, ok := value.(cadence.Optional)
+		if ok && optional.Value == nil {
+			return optional, nil
+		}
+		// value of T can be used as T?
+		inner := value
+		if ok {
+			inner = optional.Value
+		}
+		cv, err := e.cadenceValueAs(inner, t.Type)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewOptional(cv), nil
+	case cadence.ArrayType:
+		if array, ok := value.(cadence.Array); ok && array.ArrayType == nil {
+			return e.toCadenceArrayAs(array.Values, t)
+		}
+	case cadence.DictionaryType:
+		if dictionary, ok := value.(cadence.Dictionary); ok && dictionary.DictionaryType == nil {
+			pairs := []cadence.KeyValuePair{}
+			for _, pair := range dictionary.Pairs {
+				key, err := e.cadenceValueAs(pair.Key, t.KeyType)
+				if err != nil {
+					return nil, withPath(err, fmt.Sprintf("[key %s]", pair.Key))
+				}
+				cv, err := e.cadenceValueAs(pair.Value, t.ElementType)
+				if err != nil {
+					return nil, withPath(err, fmt.Sprintf("[%s]", pair.Key))
+				}
+				pairs = append(pairs, cadence.KeyValuePair{Key: key, Value: cv})
+			}
+			return cadence.NewDictionary(pairs).WithType(t), nil
+		}
+	}
+	// non-nil optional can be used as optional types only, which are checked above
+	if _, ok := value.(cadence.Optional); ok {
+		return nil, mismatchToCadence(value, typ)
+	}
+	if !isCadenceValueCompatible(value, typ) {
+		return nil, mismatchToCadence(value, typ)
+	}
+	return value, nil
+}
+
+// mismatchToCadence. error of converting go value to cadence type.
+func mismatchToCadence(value any, typ cadence.Type) error {
+	return &TypeMismatchError{CadenceType: typ, GoType: reflect.TypeOf(value), toCadence: true}
+}
+
+// isCadenceTypeCompatible. check if value of actual type can be used as expected type.
+// Path type does not tell the domain, use isCadenceValueCompatible for path values.
+func isCadenceTypeCompatible(actual cadence.Type, expected cadence.Type) bool {
+	return actual != nil && actual.ID() == expected.ID()
+}
+
+// isCadenceValueCompatible. check if value can be used as expected type, domain of path is checked too.
+func isCadenceValueCompatible(value cadence.Value, expected cadence.Type) bool {
+	if path, ok := value.(cadence.Path); ok {
+		return isPathDomainOf(path.Domain, expected)
+	}
+	return isCadenceTypeCompatible(value.Type(), expected)
+}
+
+// isPathDomainOf. check if path of domain can be used as expected path type,
+// e.g. public for PublicPath and CapabilityPath, any domain for Path.
+func isPathDomainOf(domain string, expected cadence.Type) bool {
+	switch expected.(type) {
+	case cadence.PathType:
+		return domain == "storage" || domain == "public" || domain == "private"
+	case cadence.StoragePathType:
+		return domain == "storage"
+	case cadence.PublicPathType:
+		return domain == "public"
+	case cadence.PrivatePathType:
+		return domain == "private"
+	case cadence.CapabilityPathType:
+		return domain == "public" || domain == "private"
+	}
+	return false
+}
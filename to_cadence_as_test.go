@@ -0,0 +1,327 @@
+package godence
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleToCadenceAs() {
+	cadenceValue, err := ToCadenceAs(big.NewInt(10), cadence.UInt256Type{})
+	fmt.Printf("type id: %s, err: %v", cadenceValue.Type().ID(), err)
+	//Output: type id: UInt256, err: <nil>
+}
+
+func TestToCadenceAs(t *testing.T) {
+	t.Run("big.Int to UInt256", func(t *testing.T) {
+		assert := assert.New(t)
+		script := []byte(`pub fun main(arg: UInt256): UInt256 { return arg }`)
+
+		cadenceValue, err := ToCadenceAs(big.NewInt(127), cadence.UInt256Type{})
+		assert.NoError(err)
+		assert.Equal("UInt256", cadenceValue.Type().ID())
+
+		args := []cadence.Value{cadenceValue}
+		ret, err := flowCli.ExecuteScriptAtLatestBlock(context.Background(), script, args)
+		assert.NoError(err)
+		assert.Equal(cadence.NewUInt256(127), ret)
+	})
+
+	t.Run("integers", func(t *testing.T) {
+		tests := []struct {
+			value any
+			typ   cadence.Type
+			want  cadence.Value
+		}{
+			{value: 15, typ: cadence.IntType{}, want: cadence.NewInt(15)},
+			{value: 15, typ: cadence.Int8Type{}, want: cadence.NewInt8(15)},
+			{value: uint8(15), typ: cadence.Int16Type{}, want: cadence.NewInt16(15)},
+			{value: int64(15), typ: cadence.Int32Type{}, want: cadence.NewInt32(15)},
+			{value: 15, typ: cadence.Int64Type{}, want: cadence.NewInt64(15)},
+			{value: 15, typ: cadence.Int128Type{}, want: cadence.NewInt128(15)},
+			{value: 15, typ: cadence.Int256Type{}, want: cadence.NewInt256(15)},
+			{value: 15, typ: cadence.UIntType{}, want: cadence.NewUInt(15)},
+			{value: 15, typ: cadence.UInt8Type{}, want: cadence.NewUInt8(15)},
+			{value: 15, typ: cadence.UInt16Type{}, want: cadence.NewUInt16(15)},
+			{value: 15, typ: cadence.UInt32Type{}, want: cadence.NewUInt32(15)},
+			{value: 15, typ: cadence.UInt64Type{}, want: cadence.NewUInt64(15)},
+			{value: big.NewInt(15), typ: cadence.UInt128Type{}, want: cadence.NewUInt128(15)},
+			{value: 15, typ: cadence.UInt256Type{}, want: cadence.NewUInt256(15)},
+			{value: 15, typ: cadence.Word8Type{}, want: cadence.NewWord8(15)},
+			{value: 15, typ: cadence.Word16Type{}, want: cadence.NewWord16(15)},
+			{value: 15, typ: cadence.Word32Type{}, want: cadence.NewWord32(15)},
+			{value: 15, typ: cadence.Word64Type{}, want: cadence.NewWord64(15)},
+			{value: 15.0, typ: cadence.UFix64Type{}, want: cadence.UFix64(1500000000)},
+			{value: -15.0, typ: cadence.Fix64Type{}, want: cadence.Fix64(-1500000000)},
+			{value: UFix64(1500000000), typ: cadence.Fix64Type{}, want: cadence.Fix64(1500000000)},
+		}
+		for _, tt := range tests {
+			t.Run(tt.typ.ID(), func(t *testing.T) {
+				assert := assert.New(t)
+				cadenceValue, err := ToCadenceAs(tt.value, tt.typ)
+				assert.NoError(err)
+				assert.Equal(tt.want, cadenceValue)
+			})
+		}
+	})
+
+	t.Run("integer out of range", func(t *testing.T) {
+		assert := assert.New(t)
+
+		_, err := ToCadenceAs(256, cadence.UInt8Type{})
+		assert.EqualError(err, "value 256 out of range of UInt8")
+		assert.ErrorIs(err, ErrOverflow)
+		var overflowErr *OverflowError
+		assert.ErrorAs(err, &overflowErr)
+		assert.Equal("256", overflowErr.Value)
+		assert.Equal(cadence.UInt8Type{}, overflowErr.CadenceType)
+		_, err = ToCadenceAs(-1, cadence.UInt64Type{})
+		assert.EqualError(err, "value -1 out of range of UInt64")
+		_, err = ToCadenceAs(-1.0, cadence.UFix64Type{})
+		assert.EqualError(err, "value -1.00000000 out of range of UFix64")
+	})
+
+	t.Run("integer to fixed-point", func(t *testing.T) {
+		assert := assert.New(t)
+
+		// go integers are raw values when decoding, so they are ambiguous
+		_, err := ToCadenceAs(uint64(5), cadence.UFix64Type{})
+		assert.EqualError(err, "cannot convert uint64 to UFix64")
+		assert.ErrorIs(err, ErrTypeMismatch)
+		_, err = ToCadenceAs(-5, cadence.Fix64Type{})
+		assert.EqualError(err, "cannot convert int to Fix64")
+
+		// the same representation in both directions
+		cadenceValue, err := ToCadenceAs(UFix64(5), cadence.UFix64Type{})
+		assert.NoError(err)
+		var dist uint64
+		assert.NoError(ToGo(cadenceValue, &dist))
+		assert.Equal(uint64(5), dist)
+	})
+
+	t.Run("type mismatched", func(t *testing.T) {
+		assert := assert.New(t)
+
+		_, err := ToCadenceAs("LemonNeko", cadence.UInt8Type{})
+		assert.EqualError(err, "cannot convert string to UInt8")
+		assert.ErrorIs(err, ErrTypeMismatch)
+		_, err = ToCadenceAs(15, cadence.StringType{})
+		assert.EqualError(err, "cannot convert int to String")
+		_, err = ToCadenceAs(cadence.NewInt(15), cadence.StringType{})
+		assert.EqualError(err, "cannot convert cadence.Int to String")
+	})
+
+	t.Run("other types", func(t *testing.T) {
+		tests := []struct {
+			value any
+			typ   cadence.Type
+			want  cadence.Value
+		}{
+			{value: "LemonNeko", typ: cadence.StringType{}, want: cadence.String("LemonNeko")},
+			{value: "L", typ: cadence.CharacterType{}, want: cadence.Character("L")},
+			{value: "0xf8d6e0586b0a20c7", typ: cadence.AddressType{}, want: cadence.BytesToAddress([]byte{0xf8, 0xd6, 0xe0, 0x58, 0x6b, 0x0a, 0x20, 0xc7})},
+			{value: [8]uint8{}, typ: cadence.AddressType{}, want: cadence.NewAddress([8]uint8{})},
+			{value: "/public/simpleR", typ: cadence.PublicPathType{}, want: cadence.NewPath("public", "simpleR")},
+			{value: true, typ: cadence.BoolType{}, want: cadence.NewBool(true)},
+			{value: cadence.NewInt(15), typ: cadence.IntType{}, want: cadence.NewInt(15)},
+			{value: 15, typ: cadence.AnyStructType{}, want: cadence.NewInt(15)},
+		}
+		for _, tt := range tests {
+			t.Run(tt.typ.ID(), func(t *testing.T) {
+				assert := assert.New(t)
+				cadenceValue, err := ToCadenceAs(tt.value, tt.typ)
+				assert.NoError(err)
+				assert.Equal(tt.want, cadenceValue)
+			})
+		}
+	})
+
+	t.Run("path", func(t *testing.T) {
+		assert := assert.New(t)
+
+		cadenceValue, err := ToCadenceAs("/storage/simpleR", cadence.StoragePathType{})
+		assert.NoError(err)
+		assert.Equal(cadence.NewPath("storage", "simpleR"), cadenceValue)
+		cadenceValue, err = ToCadenceAs(cadence.NewPath("private", "simpleR"), cadence.CapabilityPathType{})
+		assert.NoError(err)
+		assert.Equal(cadence.NewPath("private", "simpleR"), cadenceValue)
+
+		// invalid format
+		for _, path := range []string{"/storage", "storage/simpleR", "/storage/", "/storage/simpleR/x", "/contract/simpleR"} {
+			_, err = ToCadenceAs(path, cadence.StoragePathType{})
+			assert.ErrorIs(err, ErrTypeMismatch, path)
+		}
+		_, err = ToCadenceAs("/storage", cadence.StoragePathType{})
+		assert.EqualError(err, `invalid path "/storage": cannot convert godence.Path to Path`)
+		_, err = ToCadence(Path("/storage"))
+		assert.ErrorIs(err, ErrTypeMismatch)
+
+		// domain mismatched
+		_, err = ToCadenceAs("/public/simpleR", cadence.StoragePathType{})
+		assert.EqualError(err, "cannot convert string to StoragePath")
+		_, err = ToCadenceAs(cadence.NewPath("storage", "simpleR"), cadence.CapabilityPathType{})
+		assert.EqualError(err, "cannot convert cadence.Path to CapabilityPath")
+		_, err = ToCadenceAs("/private/simpleR", cadence.PublicPathType{})
+		assert.ErrorIs(err, ErrTypeMismatch)
+	})
+
+	t.Run("optional", func(t *testing.T) {
+		assert := assert.New(t)
+		typ := cadence.OptionalType{Type: cadence.UInt64Type{}}
+
+		cadenceValue, err := ToCadenceAs(15, typ)
+		assert.NoError(err)
+		assert.Equal(cadence.NewOptional(cadence.NewUInt64(15)), cadenceValue)
+
+		value := 15
+		cadenceValue, err = ToCadenceAs(&value, typ)
+		assert.NoError(err)
+		assert.Equal(cadence.NewOptional(cadence.NewUInt64(15)), cadenceValue)
+
+		var nilValue *int
+		cadenceValue, err = ToCadenceAs(nilValue, typ)
+		assert.NoError(err)
+		assert.Equal(cadence.NewOptional(nil), cadenceValue)
+
+		cadenceValue, err = ToCadenceAs(nil, typ)
+		assert.NoError(err)
+		assert.Equal(cadence.NewOptional(nil), cadenceValue)
+	})
+
+	t.Run("array", func(t *testing.T) {
+		assert := assert.New(t)
+		typ := cadence.VariableSizedArrayType{ElementType: cadence.UFix64Type{}}
+
+		cadenceValue, err := ToCadenceAs([]float64{1, 2}, typ)
+		assert.NoError(err)
+		assert.Equal("[UFix64]", cadenceValue.Type().ID())
+		assert.Equal([]cadence.Value{cadence.UFix64(100000000), cadence.UFix64(200000000)}, cadenceValue.(cadence.Array).Values)
+
+		_, err = ToCadenceAs([]float64{1, -2}, typ)
+		assert.EqualError(err, "[1]: value -2.00000000 out of range of UFix64")
+
+		_, err = ToCadenceAs([]int{1, 2}, cadence.ConstantSizedArrayType{Size: 3, ElementType: cadence.IntType{}})
+		assert.EqualError(err, "length 2: cannot convert []int to [Int;3]")
+		assert.ErrorIs(err, ErrTypeMismatch)
+
+		_, err = ToCadenceAs("LemonNeko", typ)
+		assert.EqualError(err, "cannot convert string to [UFix64]")
+	})
+
+	t.Run("dictionary", func(t *testing.T) {
+		assert := assert.New(t)
+		typ := cadence.OptionalType{Type: cadence.DictionaryType{KeyType: cadence.StringType{}, ElementType: cadence.Int32Type{}}}
+
+		cadenceValue, err := ToCadenceAs(map[string]int{"LemonNeko": 15}, typ)
+		assert.NoError(err)
+		assert.Equal("{String:Int32}?", cadenceValue.Type().ID())
+		pairs := cadenceValue.(cadence.Optional).Value.(cadence.Dictionary).Pairs
+		assert.Equal([]cadence.KeyValuePair{{Key: cadence.String("LemonNeko"), Value: cadence.NewInt32(15)}}, pairs)
+
+		_, err = ToCadenceAs(map[string]int{"LemonNeko": 1 << 40}, typ)
+		assert.EqualError(err, `["LemonNeko"]: value 1099511627776 out of range of Int32`)
+
+		_, err = ToCadenceAs(map[int]int{1: 1}, typ)
+		assert.EqualError(err, "[key 1]: cannot convert int to String")
+
+		_, err = ToCadenceAs([]int{1}, typ)
+		assert.EqualError(err, "cannot convert []int to {String:Int32}")
+	})
+
+	t.Run("cadence values", func(t *testing.T) {
+		assert := assert.New(t)
+
+		// any value can be used as AnyStruct
+		cadenceValue, err := ToCadenceAs(cadence.NewInt(1), cadence.AnyStructType{})
+		assert.NoError(err)
+		assert.Equal(cadence.NewInt(1), cadenceValue)
+		cadenceValue, err = ToCadenceAs(cadence.NewInt(1), cadence.OptionalType{Type: cadence.AnyStructType{}})
+		assert.NoError(err)
+		assert.Equal(cadence.NewOptional(cadence.NewInt(1)), cadenceValue)
+
+		// nil can be used as any optional type
+		cadenceValue, err = ToCadenceAs(cadence.NewOptional(nil), cadence.OptionalType{Type: cadence.IntType{}})
+		assert.NoError(err)
+		assert.Equal(cadence.NewOptional(nil), cadenceValue)
+		cadenceValue, err = ToCadenceAs(cadence.NewOptional(cadence.NewInt(1)), cadence.OptionalType{Type: cadence.IntType{}})
+		assert.NoError(err)
+		assert.Equal(cadence.NewOptional(cadence.NewInt(1)), cadenceValue)
+		_, err = ToCadenceAs(cadence.NewOptional(nil), cadence.IntType{})
+		assert.EqualError(err, "cannot convert cadence.Optional to Int")
+
+		// untyped array and dictionary are checked element by element
+		typ := cadence.VariableSizedArrayType{ElementType: cadence.IntType{}}
+		array, err := ToCadence([]int{1, 2})
+		assert.NoError(err)
+		cadenceValue, err = ToCadenceAs(array, typ)
+		assert.NoError(err)
+		assert.Equal(cadence.NewArray([]cadence.Value{cadence.NewInt(1), cadence.NewInt(2)}).WithType(typ), cadenceValue)
+		_, err = ToCadenceAs(cadence.NewArray([]cadence.Value{cadence.String("LemonNeko")}), typ)
+		assert.EqualError(err, "[0]: cannot convert cadence.String to Int")
+		_, err = ToCadenceAs(array, cadence.ConstantSizedArrayType{Size: 3, ElementType: cadence.IntType{}})
+		assert.ErrorIs(err, ErrTypeMismatch)
+
+		dictionaryType := cadence.DictionaryType{KeyType: cadence.StringType{}, ElementType: cadence.OptionalType{Type: cadence.IntType{}}}
+		dictionary := cadence.NewDictionary([]cadence.KeyValuePair{{Key: cadence.String("LemonNeko"), Value: cadence.NewOptional(nil)}})
+		cadenceValue, err = ToCadenceAs(dictionary, dictionaryType)
+		assert.NoError(err)
+		assert.Equal(dictionary.WithType(dictionaryType), cadenceValue)
+		_, err = ToCadenceAs(cadence.NewDictionary([]cadence.KeyValuePair{{Key: cadence.String("LemonNeko"), Value: cadence.String("")}}), dictionaryType)
+		assert.EqualError(err, `["LemonNeko"]: cannot convert cadence.String to Int`)
+
+		// result of registered function
+		type ids struct{}
+		encoder := NewEncoder(WithEncoderFunc(reflect.TypeOf(ids{}), func(value any) (cadence.Value, error) {
+			return cadence.NewArray([]cadence.Value{cadence.NewInt(1)}), nil
+		}))
+		cadenceValue, err = encoder.EncodeAs(ids{}, typ)
+		assert.NoError(err)
+		assert.Equal("[Int]", cadenceValue.Type().ID())
+	})
+
+	t.Run("nil type", func(t *testing.T) {
+		assert := assert.New(t)
+
+		_, err := ToCadenceAs(15, nil)
+		assert.EqualError(err, "cannot convert int to nil cadence type")
+		assert.ErrorIs(err, ErrUnsupportedType)
+		_, err = ToCadenceAs(nil, nil)
+		assert.EqualError(err, "cannot convert nil to nil cadence type")
+	})
+
+	t.Run("nil value", func(t *testing.T) {
+		assert := assert.New(t)
+
+		_, err := ToCadenceAs(nil, cadence.AnyStructType{})
+		assert.EqualError(err, "unsupport nil value, use cadence.NewOptional(nil) for cadence nil")
+		assert.ErrorIs(err, ErrUnsupportedType)
+		_, err = ToCadence(nil)
+		assert.EqualError(err, "unsupport nil value, use cadence.NewOptional(nil) for cadence nil")
+		_, err = ToCadence([]any{1, nil})
+		assert.EqualError(err, "[1]: unsupport nil value, use cadence.NewOptional(nil) for cadence nil")
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		type forEmbedded struct {
+			MyName string `godence:"myName"`
+		}
+		assert := assert.New(t)
+		typ := &cadence.StructType{
+			QualifiedIdentifier: "ForEmbedded",
+			Fields:              []cadence.Field{{Identifier: "myName", Type: cadence.StringType{}}},
+		}
+
+		cadenceValue, err := ToCadenceAs(&forEmbedded{MyName: "LemonNeko"}, typ)
+		assert.NoError(err)
+		assert.Equal(cadence.NewStruct([]cadence.Value{cadence.String("LemonNeko")}).WithType(typ), cadenceValue)
+
+		_, err = ToCadenceAs(struct{ Name string }{}, typ)
+		assert.EqualError(err, "cannot find field named myName in go struct struct { Name string }")
+		assert.ErrorIs(err, ErrFieldNotFound)
+	})
+}
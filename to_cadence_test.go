@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"reflect"
 	"testing"
 
 	"github.com/onflow/cadence"
@@ -23,17 +24,95 @@ func ExampleToCadence_bigInt() {
 }
 
 func ExampleToCadence_uFix64() {
+	// UFix64 is scaled by 1e8, use ParseUFix64 for decimal string
 	cadenceValue, err := ToCadence(UFix64(15))
+	fmt.Printf("type id: %s, value: %s, err: %v", cadenceValue.Type().ID(), cadenceValue, err)
+	//Output: type id: UFix64, value: 0.00000015, err: <nil>
+}
+
+func ExampleToCadence_struct() {
+	type forEmbedded struct {
+		MyName string `godence:"myName"`
+	}
+	err := RegisterStruct("A.f8d6e0586b0a20c7.ForTest.ForEmbedded", forEmbedded{})
+	if err != nil {
+		panic(err)
+	}
+	cadenceValue, err := ToCadence(forEmbedded{MyName: "LemonNeko"})
 	fmt.Printf("type id: %s, err: %v", cadenceValue.Type().ID(), err)
-	//Output: type id: UFix64, err: <nil>
+	//Output: type id: A.f8d6e0586b0a20c7.ForTest.ForEmbedded, err: <nil>
+}
+
+func TestRegisterStruct(t *testing.T) {
+	type simpleStruct struct {
+		MyName string
+	}
+	t.Run("not a struct", func(t *testing.T) {
+		assert := assert.New(t)
+		err := RegisterStruct("A.f8d6e0586b0a20c7.ForTest.ForEmbedded", "LemonNeko")
+		assert.EqualError(err, "register struct: not a struct type: string")
+	})
+	t.Run("invalid type id", func(t *testing.T) {
+		assert := assert.New(t)
+		err := RegisterStruct("A.f8d6e0586b0a20c7", simpleStruct{})
+		assert.Error(err)
+	})
+	t.Run("pointer to struct", func(t *testing.T) {
+		assert := assert.New(t)
+		err := RegisterStruct("A.f8d6e0586b0a20c7.ForTest.ForEmbedded", &simpleStruct{})
+		assert.NoError(err)
+
+		cadenceValue, err := ToCadence(simpleStruct{MyName: "LemonNeko"})
+		assert.NoError(err)
+		assert.Equal("A.f8d6e0586b0a20c7.ForTest.ForEmbedded", cadenceValue.Type().ID())
+	})
 }
 
 func TestToCadence(t *testing.T) {
+	t.Run("pointer to Optional", func(t *testing.T) {
+		type user struct {
+			MyName   string  `godence:"myName"`
+			Nickname *string `godence:"nickname"`
+			Age      *uint8  `godence:"age"`
+		}
+		assert := assert.New(t)
+		assert.NoError(RegisterStruct("A.f8d6e0586b0a20c7.ForTest.User", user{}))
+		name := "LemonNeko"
+
+		cadenceValue, err := ToCadence(&name)
+		assert.NoError(err)
+		assert.Equal(cadence.NewOptional(cadence.String("LemonNeko")), cadenceValue)
+
+		var nilName *string
+		cadenceValue, err = ToCadence(nilName)
+		assert.NoError(err)
+		assert.Equal(cadence.NewOptional(nil), cadenceValue)
+		var nilBig *big.Int
+		cadenceValue, err = ToCadence(nilBig)
+		assert.NoError(err)
+		assert.Equal(cadence.NewOptional(nil), cadenceValue)
+
+		// decoded struct can be sent back
+		cadenceValue, err = ToCadence(user{MyName: "LemonNeko", Nickname: &name})
+		assert.NoError(err)
+		s := cadenceValue.(cadence.Struct)
+		assert.Equal([]cadence.Value{cadence.String("LemonNeko"), cadence.NewOptional(cadence.String("LemonNeko")), cadence.NewOptional(nil)}, s.Fields)
+		assert.Equal("String?", s.StructType.Fields[1].Type.ID())
+
+		dist := user{}
+		assert.NoError(ToGo(cadenceValue, &dist))
+		assert.Equal(user{MyName: "LemonNeko", Nickname: &name}, dist)
+	})
+
 	t.Run("unsupport type", func(t *testing.T) {
 		assert := assert.New(t)
-		cadenceValue, err := ToCadence(unsupportType(""))
+		cadenceValue, err := ToCadence(unsupportType(0))
 		assert.Nil(cadenceValue)
 		assert.EqualError(err, "unsupport type: godence.unsupportType")
+
+		var unsupportedErr *UnsupportedTypeError
+		assert.ErrorAs(err, &unsupportedErr)
+		assert.Equal(reflect.TypeOf(unsupportType(0)), unsupportedErr.GoType)
 	})
 	t.Run("to int", func(t *testing.T) {
 		assert := assert.New(t)
@@ -260,6 +339,7 @@ func TestToCadence(t *testing.T) {
 		cadenceValue, err := ToCadence(Fix64(15))
 		assert.NoError(err)
 		assert.Equal(cadenceValue.Type().ID(), "Fix64")
+		assert.Equal("0.00000015", cadenceValue.String())
 
 		args := []cadence.Value{cadenceValue}
 		ret, err := flowCli.ExecuteScriptAtLatestBlock(context.Background(), script, args)
@@ -273,6 +353,7 @@ func TestToCadence(t *testing.T) {
 		cadenceValue, err := ToCadence(UFix64(15))
 		assert.NoError(err)
 		assert.Equal(cadenceValue.Type().ID(), "UFix64")
+		assert.Equal("0.00000015", cadenceValue.String())
 
 		args := []cadence.Value{cadenceValue}
 		ret, err := flowCli.ExecuteScriptAtLatestBlock(context.Background(), script, args)
@@ -363,8 +444,9 @@ func TestToCadence(t *testing.T) {
 	t.Run("unsupport to Array", func(t *testing.T) {
 		assert := assert.New(t)
 
-		cadenceValue, err := ToCadence([...]unsupportType{"My", "Name", "Is", "LemonNeko"})
-		assert.EqualError(err, "unsupport type: godence.unsupportType")
+		cadenceValue, err := ToCadence([...]unsupportType{1, 2, 3})
+		assert.EqualError(err, "[0]: unsupport type: godence.unsupportType")
+		assert.ErrorIs(err, ErrUnsupportedType)
 		assert.Nil(cadenceValue)
 	})
 
@@ -382,19 +464,57 @@ func TestToCadence(t *testing.T) {
 		assert.True(ret.ToGoValue().(bool))
 	})
 
+	t.Run("struct to Struct", func(t *testing.T) {
+		type forEmbedded struct {
+			MyName string `godence:"myName"`
+			ignore string
+		}
+		assert := assert.New(t)
+		err := RegisterStruct("A.f8d6e0586b0a20c7.ForTest.ForEmbedded", forEmbedded{})
+		assert.NoError(err)
+		script := []byte(`
+import ForTest from 0xf8d6e0586b0a20c7
+
+pub fun main(arg: ForTest.ForEmbedded): String {
+	return arg.myName
+}`)
+
+		cadenceValue, err := ToCadence(&forEmbedded{MyName: "LemonNeko"})
+		assert.NoError(err)
+		assert.Equal("A.f8d6e0586b0a20c7.ForTest.ForEmbedded", cadenceValue.Type().ID())
+		assert.Equal([]cadence.Value{cadence.String("LemonNeko")}, cadenceValue.(cadence.Struct).Fields)
+
+		args := []cadence.Value{cadenceValue}
+		ret, err := flowCli.ExecuteScriptAtLatestBlock(context.Background(), script, args)
+		assert.NoError(err)
+		assert.Equal(cadence.String("LemonNeko"), ret)
+	})
+
+	t.Run("struct to Struct, unregistered", func(t *testing.T) {
+		type unregistered struct {
+			MyName string
+		}
+		assert := assert.New(t)
+
+		cadenceValue, err := ToCadence(unregistered{MyName: "LemonNeko"})
+		assert.EqualError(err, "unregistered struct type: godence.unregistered")
+		assert.ErrorIs(err, ErrUnsupportedType)
+		assert.Nil(cadenceValue)
+	})
+
 	t.Run("map to Dictionary, unsupport key type", func(t *testing.T) {
 		assert := assert.New(t)
 
-		cadenceValue, err := ToCadence(map[unsupportType]string{"MyName": "LemonNeko"})
-		assert.EqualError(err, "unsupport type: godence.unsupportType")
+		cadenceValue, err := ToCadence(map[unsupportType]string{1: "LemonNeko"})
+		assert.EqualError(err, `[key (1+0i)]: unsupport type: godence.unsupportType`)
 		assert.Nil(cadenceValue)
 	})
 
 	t.Run("map to Dictionary, unsupport value type", func(t *testing.T) {
 		assert := assert.New(t)
 
-		cadenceValue, err := ToCadence(map[string]unsupportType{"MyName": "LemonNeko"})
-		assert.EqualError(err, "unsupport type: godence.unsupportType")
+		cadenceValue, err := ToCadence(map[string]unsupportType{"MyName": 1})
+		assert.EqualError(err, `["MyName"]: unsupport type: godence.unsupportType`)
 		assert.Nil(cadenceValue)
 	})
 }
@@ -615,6 +735,7 @@ func TestToCadenceOptional(t *testing.T) {
 		cadenceValue, err := ToCadenceOptional(Fix64(15))
 		assert.NoError(err)
 		assert.Equal(cadenceValue.Type().ID(), "Fix64?")
+		assert.Equal(cadence.NewOptional(cadence.Fix64(15)), cadenceValue)
 
 		args := []cadence.Value{cadenceValue}
 		ret, err := flowCli.ExecuteScriptAtLatestBlock(context.Background(), script, args)
@@ -628,6 +749,7 @@ func TestToCadenceOptional(t *testing.T) {
 		cadenceValue, err := ToCadenceOptional(UFix64(15))
 		assert.NoError(err)
 		assert.Equal(cadenceValue.Type().ID(), "UFix64?")
+		assert.Equal(cadence.NewOptional(cadence.UFix64(15)), cadenceValue)
 
 		args := []cadence.Value{cadenceValue}
 		ret, err := flowCli.ExecuteScriptAtLatestBlock(context.Background(), script, args)
@@ -676,7 +798,7 @@ func TestToCadenceOptional(t *testing.T) {
 	t.Run("error", func(t *testing.T) {
 		assert := assert.New(t)
 
-		_, err := ToCadenceOptional(unsupportType(""))
+		_, err := ToCadenceOptional(unsupportType(0))
 		assert.EqualError(err, "unsupport type: godence.unsupportType")
 	})
 }
@@ -705,3 +827,69 @@ pub fun main(): SimpleStruct {
 		assert.Equal(ret.Type().ID(), "s.b37650809c8bd4cb0827a55bc447099f8f8ac555ef5308bfc287f757557430ef.SimpleStruct")
 	})
 }
+
+func TestNamedTypesToCadence(t *testing.T) {
+	type username string
+	type score uint32
+	type price float64
+	type flag bool
+
+	t.Run("ToCadence", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			value any
+			want  cadence.Value
+		}{
+			{name: "string", value: username("LemonNeko"), want: cadence.String("LemonNeko")},
+			{name: "uint32", value: score(15), want: cadence.NewUInt32(15)},
+			{name: "float64", value: price(0.5), want: cadence.Fix64(50000000)},
+			{name: "bool", value: flag(true), want: cadence.NewBool(true)},
+			{name: "slice", value: []username{"LemonNeko"}, want: cadence.NewArray([]cadence.Value{cadence.String("LemonNeko")})},
+			{name: "map", value: map[username]score{"LemonNeko": 15}, want: cadence.NewDictionary([]cadence.KeyValuePair{{Key: cadence.String("LemonNeko"), Value: cadence.NewUInt32(15)}})},
+			{name: "helper type keeps its meaning", value: Address("0xf8d6e0586b0a20c7"), want: cadence.BytesToAddress([]byte{0xf8, 0xd6, 0xe0, 0x58, 0x6b, 0x0a, 0x20, 0xc7})},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert := assert.New(t)
+				cadenceValue, err := ToCadence(tt.value)
+				assert.NoError(err)
+				assert.Equal(tt.want, cadenceValue)
+			})
+		}
+	})
+
+	t.Run("ToCadenceAs", func(t *testing.T) {
+		assert := assert.New(t)
+
+		cadenceValue, err := ToCadenceAs(username("0xf8d6e0586b0a20c7"), cadence.AddressType{})
+		assert.NoError(err)
+		assert.Equal("Address", cadenceValue.Type().ID())
+
+		cadenceValue, err = ToCadenceAs(price(0.5), cadence.UFix64Type{})
+		assert.NoError(err)
+		assert.Equal(cadence.UFix64(50000000), cadenceValue)
+
+		cadenceValue, err = ToCadenceAs(score(15), cadence.UInt8Type{})
+		assert.NoError(err)
+		assert.Equal(cadence.NewUInt8(15), cadenceValue)
+
+		_, err = ToCadenceAs(flag(true), cadence.StringType{})
+		assert.EqualError(err, "cannot convert godence.flag to String")
+	})
+
+	t.Run("script argument", func(t *testing.T) {
+		assert := assert.New(t)
+		script := []byte(`pub fun main(arg: UInt32): UInt32 { return arg }`)
+
+		cadenceValue, err := ToCadence(score(15))
+		assert.NoError(err)
+
+		args := []cadence.Value{cadenceValue}
+		ret, err := flowCli.ExecuteScriptAtLatestBlock(context.Background(), script, args)
+		assert.NoError(err)
+
+		var dist score
+		assert.NoError(ToGo(ret, &dist))
+		assert.Equal(score(15), dist)
+	})
+}
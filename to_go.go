@@ -4,150 +4,239 @@ import (
 	"fmt"
 	"math/big"
 	"reflect"
+	"strconv"
+	"strings"
 
 	"github.com/onflow/cadence"
 )
 
-// getFieldByName. find cadence field by go field name.
-func getFieldByName(name string, value cadence.Value) (cadence.Value, error) {
-	switch v := value.(type) {
-	case cadence.Struct:
-		for index, field := range v.StructType.Fields {
-			if name == field.Identifier {
-				return v.Fields[index], nil
-			}
+// structEventResourceToGoStruct
+func (d *Decoder) structEventResourceToGoStruct(value cadence.Value, dist any) error {
+	distT := reflect.TypeOf(dist)
+	distV := reflect.ValueOf(dist)
+
+	typ, fields, values, ok := compositeFieldsOf(value)
+	if !ok {
+		return mismatchToGo(value, distT.Elem())
+	}
+	plan := structPlanOf(distT.Elem(), typ, fields, d.naming)
+	if plan.err != nil {
+		return plan.err
+	}
+	if plan.remain >= 0 {
+		// cadence fields without go field are collected by the remain field
+		if err := d.toGoRemain(fields, values, plan.unmapped, distV.Elem().Field(plan.remain)); err != nil {
+			return err
 		}
-	case cadence.Event:
-		for index, field := range v.EventType.Fields {
-			if name == field.Identifier {
-				return v.Fields[index], nil
+	} else {
+		// report cadence fields without go field
+		if d.unmappedHandler != nil {
+			for _, index := range plan.unmapped {
+				if err := d.unmappedHandler(typ, fields[index].Identifier, values[index]); err != nil {
+					return err
+				}
 			}
 		}
-	case cadence.Resource:
-		for index, field := range v.ResourceType.Fields {
-			if name == field.Identifier {
-				return v.Fields[index], nil
+		if d.strict && len(plan.unmapped) > 0 {
+			return &FieldNotFoundError{Field: fields[plan.unmapped[0]].Identifier, CadenceType: typ, GoType: distT.Elem(), missingInGo: true}
+		}
+	}
+	// traverse all dist fields by the cached plan.
+	for _, field := range plan.fields {
+		if field.cadenceIndex < 0 {
+			// leave the go field unchanged
+			if field.tag.optional && !d.requireAll {
+				continue
 			}
+			return &FieldNotFoundError{Field: field.name, CadenceType: typ, GoType: distT.Elem()}
+		}
+		fieldV := distV.Elem().Field(field.goIndex)
+		if err := checkTypeHint(values[field.cadenceIndex], field.tag.typ, fieldV.Type()); err != nil {
+			return withPath(err, field.name)
+		}
+		// decode the same as ToGo, optional to pointer
+		if err := d.toGoElement(values[field.cadenceIndex], fieldV); err != nil {
+			return withPath(err, field.name)
 		}
 	}
-	// not found, return void and error
-	return cadence.NewVoid(), fmt.Errorf("cannot find field named %s in cadence struct/event/resource", name)
+	return nil
 }
 
-// structEventResourceToGoStruct
-func structEventResourceToGoStruct(value cadence.Value, dist any) (err error) {
-	distT := reflect.TypeOf(dist)
-	distV := reflect.ValueOf(dist)
-
-	defer func() {
-		if msg := recover(); msg != nil {
-			err = fmt.Errorf("structEventResourceToGoStruct, panic recoverd: %v", msg)
+// toGoRemain. collect cadence fields into the go field tagged remain, map[string]cadence.Value or map[string]any.
+// Values of map[string]any are decoded the same as ToGo, nil map will be allocated.
+func (d *Decoder) toGoRemain(fields []cadence.Field, values []cadence.Value, indexes []int, dist reflect.Value) error {
+	if dist.IsNil() {
+		dist.Set(reflect.MakeMapWithSize(dist.Type(), len(indexes)))
+	}
+	for _, index := range indexes {
+		name := fields[index].Identifier
+		valueV := reflect.New(dist.Type().Elem()).Elem()
+		if dist.Type().Elem() == cadenceValueType {
+			valueV.Set(reflect.ValueOf(values[index]))
+		} else if err := d.toGoElement(values[index], valueV); err != nil {
+			return withPath(err, name)
 		}
-	}()
+		dist.SetMapIndex(reflect.ValueOf(name).Convert(dist.Type().Key()), valueV)
+	}
+	return nil
+}
 
-	// traverse all dist fields.
-	for fieldIndex := 0; fieldIndex < distT.Elem().NumField(); fieldIndex++ {
-		fieldT := distT.Elem().Field(fieldIndex)
-		fieldV := distV.Elem().Field(fieldIndex)
-		// cannot set, skip
-		if !fieldV.CanSet() {
-			continue
-		}
-		// find cadence field by go field
-		fieldName := fieldT.Name
-		if tagValue, ok := fieldT.Tag.Lookup("godence"); ok {
-			// get cadence field name specified by tag
-			fieldName = tagValue
-		}
-		// if error
-		if v, err := getFieldByName(fieldName, value); err == nil {
-			if fieldV.Kind() == reflect.Pointer {
-				// if big int
-				if fieldT.Type.Elem().PkgPath() == "math/big" && fieldT.Type.Elem().Name() == "Int" {
-					fieldV.Set(reflect.ValueOf(v.ToGoValue()))
-				} else {
-					// embedded struct
-					// should use type to get kind
-					if fieldT.Type.Elem().Kind() == reflect.Struct {
-						// check nil
-						if fieldV.IsNil() {
-							// new a value
-							fieldV.Set(reflect.New(fieldT.Type.Elem()))
-						}
-						toGoStruct(v, fieldV.Interface())
-					}
-				}
-			} else {
-				toGoReflect(v, &fieldV)
-			}
-		} else if err != nil {
-			return err
-		}
+// fixedPointToFloat. convert Fix64 or UFix64 to the nearest float, bitSize is 32 for float32, 64 for float64.
+func fixedPointToFloat(value cadence.Value, bitSize int) float64 {
+	switch value.(type) {
+	case cadence.Fix64, cadence.UFix64:
+		f, _ := strconv.ParseFloat(value.String(), bitSize)
+		return f
 	}
-	return
+	panic(fmt.Sprintf("cannot convert %s to float", value.Type().ID()))
 }
 
-// toGoReflect. the same as ToGo, but receive reflect.Value
-func toGoReflect(value cadence.Value, dist *reflect.Value) error {
-	switch dist.Type().String() {
-	default:
-		dist.Set(reflect.ValueOf(value.ToGoValue()))
-		return nil
-	// other
-	case "string": // Cadence String, Address, Path, Character(why?)
-		switch cv := value.(type) {
-		case cadence.Address, cadence.Path:
-			dist.Set(reflect.ValueOf(cv.String()))
+// mismatchToGo. error of converting cadence value to go type, untyped value is reported by its go type.
+func mismatchToGo(value cadence.Value, goType reflect.Type) error {
+	err := &TypeMismatchError{CadenceType: value.Type(), GoType: goType}
+	if err.CadenceType == nil {
+		err.valueKind = fmt.Sprintf("%T", value)
+	}
+	return err
+}
+
+// isNilOptional. check if value is cadence nil.
+func isNilOptional(value cadence.Value) bool {
+	optional, ok := value.(cadence.Optional)
+	return ok && optional.Value == nil
+}
+
+// isNilOrVoid. check if value is cadence nil or Void, without converting nested values.
+func isNilOrVoid(value cadence.Value) bool {
+	switch v := value.(type) {
+	case nil, cadence.Void:
+		return true
+	case cadence.Optional:
+		return v.Value == nil
+	}
+	return false
+}
+
+// toGoElement. decode value into an addressable reflect.Value, the same as ToGo.
+// Pointer will be allocated if value is not nil, nil optional will be nil pointer.
+// Registered pointer types are left to the registered function.
+func (d *Decoder) toGoElement(value cadence.Value, dist reflect.Value) error {
+	if dist.Kind() == reflect.Pointer && dist.Type() != reflect.TypeOf(&big.Int{}) && !d.hasDecoder(dist.Type()) {
+		if isNilOptional(value) {
+			dist.Set(reflect.Zero(dist.Type()))
 			return nil
 		}
-		dist.Set(reflect.ValueOf(value.ToGoValue()))
+		// the pointer is the optional, convert the inner value
+		if optional, ok := value.(cadence.Optional); ok {
+			value = optional.Value
+		}
+		ptr := reflect.New(dist.Type().Elem())
+		if err := d.toGoElement(value, ptr.Elem()); err != nil {
+			return err
+		}
+		dist.Set(ptr)
 		return nil
 	}
+	return d.toGo(value, dist.Addr().Interface())
 }
 
-// toGoMap. call this function if type of dist is map kind.
-func toGoMap(value cadence.Value, dist any) (err error) {
-	defer func() {
-		if e := recover(); e != nil {
-			err = fmt.Errorf("toGoMap, panic recoverd: %v", e)
-		}
-	}()
-	dic := value.(cadence.Dictionary)
+// toGoMap. call this function if type of dist is map kind or pointer to map.
+// Keys and values are decoded the same as ToGo, nil map will be allocated if dist is pointer.
+func (d *Decoder) toGoMap(value cadence.Value, dist any) error {
+	if optional, ok := value.(cadence.Optional); ok {
+		return d.toGoMap(optional.Value, dist)
+	}
 	distV := reflect.ValueOf(dist)
+	dic, ok := value.(cadence.Dictionary)
+	if !ok {
+		return mismatchToGo(value, distV.Type())
+	}
+	if distV.Kind() == reflect.Pointer {
+		distV = distV.Elem()
+		if distV.IsNil() {
+			distV.Set(reflect.MakeMapWithSize(distV.Type(), len(dic.Pairs)))
+		}
+	}
+	if distV.IsNil() {
+		return &InvalidDestinationError{GoType: distV.Type()}
+	}
 	for _, retEntry := range dic.Pairs {
-		distV.SetMapIndex(reflect.ValueOf(retEntry.Key.ToGoValue()), reflect.ValueOf(retEntry.Value.ToGoValue()))
+		keyV := reflect.New(distV.Type().Key()).Elem()
+		if err := d.toGoElement(retEntry.Key, keyV); err != nil {
+			return withPath(err, fmt.Sprintf("[key %s]", retEntry.Key))
+		}
+		valueV := reflect.New(distV.Type().Elem()).Elem()
+		if err := d.toGoElement(retEntry.Value, valueV); err != nil {
+			return withPath(err, fmt.Sprintf("[%s]", retEntry.Key))
+		}
+		distV.SetMapIndex(keyV, valueV)
 	}
-	return
+	return nil
 }
 
-// toGoSlice. call this function if type of dist is array kind.
-func toGoSlice(value cadence.Value, dist any) (err error) {
-	defer func() {
-		if e := recover(); e != nil {
-			err = fmt.Errorf("toGoSlice, panic recoverd: %v", e)
+// toGoSlice. call this function if type of dist is slice kind.
+// Existing elements are dropped, elements are decoded the same as ToGo.
+func (d *Decoder) toGoSlice(value cadence.Value, dist any) error {
+	if optional, ok := value.(cadence.Optional); ok {
+		return d.toGoSlice(optional.Value, dist)
+	}
+	distV := reflect.ValueOf(dist).Elem()
+	dic, ok := value.(cadence.Array)
+	if !ok {
+		return mismatchToGo(value, distV.Type())
+	}
+	// reuse the backing array, but not the elements, the same as encoding/json
+	if distV.IsNil() || distV.Cap() < len(dic.Values) {
+		distV.Set(reflect.MakeSlice(distV.Type(), len(dic.Values), len(dic.Values)))
+	} else {
+		distV.Set(distV.Slice(0, len(dic.Values)))
+		for i := 0; i < distV.Len(); i++ {
+			distV.Index(i).Set(reflect.Zero(distV.Type().Elem()))
 		}
-	}()
-	dic := value.(cadence.Array)
+	}
+	for index, retElment := range dic.Values {
+		if err := d.toGoElement(retElment, distV.Index(index)); err != nil {
+			return withPath(err, fmt.Sprintf("[%d]", index))
+		}
+	}
+	return nil
+}
+
+// toGoArray. call this function if type of dist is go array kind, length of cadence array should be the same.
+// Elements are decoded the same as ToGo.
+func (d *Decoder) toGoArray(value cadence.Value, dist any) error {
+	if optional, ok := value.(cadence.Optional); ok {
+		return d.toGoArray(optional.Value, dist)
+	}
 	distV := reflect.ValueOf(dist).Elem()
-	for _, retElment := range dic.Values {
-		distV.Set(reflect.Append(distV, reflect.ValueOf(retElment.ToGoValue())))
+	array, ok := value.(cadence.Array)
+	if !ok {
+		return mismatchToGo(value, distV.Type())
+	}
+	if len(array.Values) != distV.Len() {
+		return fmt.Errorf("length %d: %w", len(array.Values), mismatchToGo(value, distV.Type()))
+	}
+	for index, element := range array.Values {
+		if err := d.toGoElement(element, distV.Index(index)); err != nil {
+			return withPath(err, fmt.Sprintf("[%d]", index))
+		}
 	}
-	return
+	return nil
 }
 
 // toGoStruct. call this function if type of dist is struct kind.
-func toGoStruct(value cadence.Value, dist any) error {
+func (d *Decoder) toGoStruct(value cadence.Value, dist any) error {
 	switch v := value.(type) {
 	case cadence.Optional:
-		return toGoStruct(v.Value, dist)
+		return d.toGoStruct(v.Value, dist)
 	case cadence.Struct:
-		return structEventResourceToGoStruct(v, dist)
+		return d.structEventResourceToGoStruct(v, dist)
 	case cadence.Event:
-		return structEventResourceToGoStruct(v, dist)
+		return d.structEventResourceToGoStruct(v, dist)
 	case cadence.Resource:
-		return structEventResourceToGoStruct(v, dist)
+		return d.structEventResourceToGoStruct(v, dist)
 	}
-	return fmt.Errorf("to go struct: unsupport cadence type: %s", reflect.TypeOf(value))
+	return mismatchToGo(value, reflect.TypeOf(dist).Elem())
 }
 
 func isValueAddressOrPath(value cadence.Value) bool {
@@ -158,12 +247,165 @@ func isValueAddressOrPath(value cadence.Value) bool {
 	return false
 }
 
-// ToGo. Convert cadence types to go.
+// compositeName. name of struct, event or resource type, without contract name.
+func compositeName(value cadence.Value) string {
+	var qualifiedIdentifier string
+	switch v := value.(type) {
+	case cadence.Optional:
+		return compositeName(v.Value)
+	case cadence.Struct:
+		if v.StructType != nil {
+			qualifiedIdentifier = v.StructType.QualifiedIdentifier
+		}
+	case cadence.Event:
+		if v.EventType != nil {
+			qualifiedIdentifier = v.EventType.QualifiedIdentifier
+		}
+	case cadence.Resource:
+		if v.ResourceType != nil {
+			qualifiedIdentifier = v.ResourceType.QualifiedIdentifier
+		}
+	}
+	return qualifiedIdentifier[strings.LastIndex(qualifiedIdentifier, ".")+1:]
+}
+
+// ToGo. Convert cadence types to go, by the default decoder.
 // Param 1: cadence value to convert.
 // Param 2: go pointer.
+// See Decoder.Decode for the rules.
+func ToGo(value cadence.Value, dist any) error {
+	return defaultDecoder.Decode(value, dist)
+}
+
+// Decode Convert cadence value to go, dist should be a go pointer or a non-nil map.
 // Address convert to string, will have 0x prefix.
-func ToGo(value cadence.Value, dist any) (err error) {
-	// type cast may be failed, should recover panic
+// Helper types use the same representation as ToCadence, so ToGo(ToCadence(x)) == x,
+// e.g. UFix64 and Fix64 are scaled by 1e8, Address is 0x prefixed.
+// Named types convert the same as their underlying kind, e.g. type Username string.
+// Types registered by RegisterDecoder will convert by the registered function.
+// Types implement CadenceUnmarshaler will convert by UnmarshalCadence.
+// Go type cadence.Value and Raw keep the cadence value untouched, to decode later.
+// If a nested value failed to convert, path of error is like ManyType.p.myName.
+// Use errors.Is or errors.As to check the kind of error, see ErrTypeMismatch and others.
+func (d *Decoder) Decode(value cadence.Value, dist any) error {
+	distV := reflect.ValueOf(dist)
+	if dist == nil || (distV.Kind() != reflect.Pointer && distV.Kind() != reflect.Map) || (distV.Kind() == reflect.Pointer && distV.IsNil()) {
+		return &InvalidDestinationError{GoType: reflect.TypeOf(dist)}
+	}
+	err := d.toGo(value, dist)
+	// start the path with type name
+	if errorPath(err) != "" && compositeName(value) != "" {
+		return withPath(err, compositeName(value))
+	}
+	return err
+}
+
+// toGoBasic. convert to integers, fixed-point numbers, strings, addresses and other basic types,
+// type of cadence value is checked before converting. Return false if dist is not a basic type.
+func toGoBasic(value cadence.Value, dist any) (bool, error) {
+	switch v := dist.(type) {
+	// integers
+	case **big.Int: // Cadence Int, Int128, Int256, UInt, UInt128, UInt256, and other integers
+		if i, ok := cadenceIntegerToBig(value); ok {
+			*v = i
+			return true, nil
+		}
+	// fixed-point numbers
+	case *Fix64:
+		if f, ok := value.(cadence.Fix64); ok {
+			*v = Fix64(f)
+			return true, nil
+		}
+	case *UFix64:
+		if f, ok := value.(cadence.UFix64); ok {
+			*v = UFix64(f)
+			return true, nil
+		}
+	case *float64: // Cadence Fix64, UFix64, the nearest float
+		switch value.(type) {
+		case cadence.Fix64, cadence.UFix64:
+			*v = fixedPointToFloat(value, 64)
+			return true, nil
+		}
+	case *float32:
+		switch value.(type) {
+		case cadence.Fix64, cadence.UFix64:
+			*v = float32(fixedPointToFloat(value, 32))
+			return true, nil
+		}
+	// other
+	case *string: // Cadence String, Address, Path, Character(why?)
+		switch s := value.(type) {
+		case cadence.Address, cadence.Path:
+			*v = value.String()
+			return true, nil
+		case cadence.String:
+			*v = string(s)
+			return true, nil
+		case cadence.Character:
+			*v = string(s)
+			return true, nil
+		}
+	case *[8]uint8: // Address, or decoded as go array
+		if a, ok := value.(cadence.Address); ok {
+			*v = a
+			return true, nil
+		}
+		return false, nil
+	case *cadence.Address: // Address
+		if a, ok := value.(cadence.Address); ok {
+			*v = a
+			return true, nil
+		}
+	// helper types, ToGo(ToCadence(x)) == x
+	case *Address: // Address, will have 0x prefix
+		if a, ok := value.(cadence.Address); ok {
+			*v = Address(a.String())
+			return true, nil
+		}
+	case *Path:
+		if p, ok := value.(cadence.Path); ok {
+			*v = Path(p.String())
+			return true, nil
+		}
+	case *Character:
+		if c, ok := value.(cadence.Character); ok {
+			*v = Character(c)
+			return true, nil
+		}
+	case *bool:
+		if b, ok := value.(cadence.Bool); ok {
+			*v = bool(b)
+			return true, nil
+		}
+	case *any:
+		*v = value.ToGoValue()
+		return true, nil
+	default:
+		return false, nil
+	}
+	// type of dist is known, but type of cadence value is not matched
+	return true, mismatchToGo(value, reflect.TypeOf(dist).Elem())
+}
+
+// toGoNamed. convert to named type of string, bool or float, e.g. type Username string.
+// Value is converted to the basic type of the same kind first.
+func toGoNamed(value cadence.Value, distV reflect.Value) error {
+	basic := reflect.New(basicTypes[distV.Kind()])
+	if _, err := toGoBasic(value, basic.Interface()); err != nil {
+		// report the named type
+		if mismatchErr, ok := err.(*TypeMismatchError); ok {
+			mismatchErr.GoType = distV.Type()
+		}
+		return err
+	}
+	distV.Set(basic.Elem().Convert(distV.Type()))
+	return nil
+}
+
+// toGo. the same as ToGo, but the path of error does not start with type name.
+func (d *Decoder) toGo(value cadence.Value, dist any) (err error) {
+	// type of cadence value is checked before converting, recover is the last resort for unknown bugs
 	defer func() {
 		if rec := recover(); rec != nil {
 			err = fmt.Errorf("panic recovered: %v", rec)
@@ -171,71 +413,65 @@ func ToGo(value cadence.Value, dist any) (err error) {
 		// defer function has no return expression.
 		// should use named return value.
 	}()
-	goTypeV := value.ToGoValue()
-	// check if optional is nil
-	if !isValueAddressOrPath(value) && goTypeV == nil {
-		reflect.ValueOf(dist).Elem().Set(reflect.Zero(reflect.TypeOf(dist).Elem()))
-		return
+	// convert by the registered function
+	if t := reflect.TypeOf(dist); t.Kind() == reflect.Pointer {
+		if fn, ok := d.decoderOf(t.Elem()); ok {
+			return fn(value, dist)
+		}
 	}
-	switch v := dist.(type) {
-	// integers
-	case **big.Int: // Cadence Int, Int128, Int256, UInt, UInt128, UInt256
-		*v = value.ToGoValue().(*big.Int)
-		return nil
-	case *int8:
-		*v = value.ToGoValue().(int8)
-		return nil
-	case *int16:
-		*v = value.ToGoValue().(int16)
-		return nil
-	case *int32:
-		*v = value.ToGoValue().(int32)
-		return nil
-	case *int64: // Cadence Int64, Fix64
-		*v = value.ToGoValue().(int64)
-		return nil
-	// unsigned integers
-	case *uint8:
-		*v = value.ToGoValue().(uint8)
-		return nil
-	case *uint16:
-		*v = value.ToGoValue().(uint16)
-		return nil
-	case *uint32:
-		*v = value.ToGoValue().(uint32)
-		return nil
-	case *uint64: // Cadence UInt64, UFix64
-		*v = value.ToGoValue().(uint64)
+	// convert by the type itself
+	if u, ok := dist.(CadenceUnmarshaler); ok {
+		return u.UnmarshalCadence(value)
+	}
+	// keep the cadence value untouched, including optional and nil
+	if v, ok := dist.(*cadence.Value); ok {
+		*v = value
 		return nil
-	// other
-	case *string: // Cadence String, Address, Path, Character(why?)
-		if isValueAddressOrPath(value) {
-			*v = value.String()
-			return
+	}
+	// nil optional or void is zero value.
+	// check the cadence value only, ToGoValue will convert the whole nested value.
+	if isNilOrVoid(value) {
+		// map cannot be set to nil, entries are kept the same as decoding a dictionary
+		if reflect.TypeOf(dist).Kind() == reflect.Map {
+			return nil
 		}
-		*v = value.ToGoValue().(string)
-		return nil
-	case *[8]uint8: // Address
-		*v = value.ToGoValue().([8]uint8)
-		return nil
-	case *cadence.Address: // Address
-		*v = value.(cadence.Address)
-		return nil
-	case *bool:
-		*v = value.ToGoValue().(bool)
+		reflect.ValueOf(dist).Elem().Set(reflect.Zero(reflect.TypeOf(dist).Elem()))
 		return nil
 	}
+	// not nil, convert the inner value
+	if optional, ok := value.(cadence.Optional); ok {
+		return d.toGo(optional.Value, dist)
+	}
+	// integers of other size or sign
+	if d.integerPolicy == IntegerExact && reflect.TypeOf(dist).Kind() == reflect.Pointer && !isExactInteger(value, reflect.TypeOf(dist).Elem()) {
+		return mismatchToGo(value, reflect.TypeOf(dist).Elem())
+	}
+	if ok, err := toGoBasic(value, dist); ok {
+		return err
+	}
 	switch reflect.TypeOf(dist).Kind() {
 	// try to convert to struct type
 	case reflect.Pointer:
 		switch reflect.TypeOf(dist).Elem().Kind() {
 		case reflect.Struct:
-			return toGoStruct(value, dist)
+			return d.toGoStruct(value, dist)
 		case reflect.Slice:
-			return toGoSlice(value, dist)
+			return d.toGoSlice(value, dist)
+		case reflect.Array:
+			return d.toGoArray(value, dist)
+		case reflect.Map:
+			return d.toGoMap(value, dist)
+		case reflect.Pointer:
+			return d.toGoElement(value, reflect.ValueOf(dist).Elem())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			// any go integer kind, including named types
+			return toGoInteger(value, reflect.ValueOf(dist).Elem())
+		case reflect.String, reflect.Bool, reflect.Float32, reflect.Float64:
+			return toGoNamed(value, reflect.ValueOf(dist).Elem())
 		}
 	case reflect.Map:
-		return toGoMap(value, dist)
+		return d.toGoMap(value, dist)
 	}
-	return fmt.Errorf("unsupport type: %s", reflect.TypeOf(dist))
+	return &UnsupportedTypeError{CadenceType: value.Type(), GoType: reflect.TypeOf(dist)}
 }
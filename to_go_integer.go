@@ -0,0 +1,159 @@
+package godence
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+
+	"github.com/onflow/cadence"
+)
+
+// bigIntegerOf. Int, Int128, Int256, UInt, UInt128 and UInt256 are stored in big.Int.
+func bigIntegerOf(value cadence.Value) (*big.Int, bool) {
+	switch v := value.(type) {
+	case cadence.Int:
+		return v.Big(), true
+	case cadence.Int128:
+		return v.Big(), true
+	case cadence.Int256:
+		return v.Big(), true
+	case cadence.UInt:
+		return v.Big(), true
+	case cadence.UInt128:
+		return v.Big(), true
+	case cadence.UInt256:
+		return v.Big(), true
+	}
+	return nil, false
+}
+
+// cadenceIntegerToBig. convert any cadence integer to a new big.Int, return false if value is not an integer.
+func cadenceIntegerToBig(value cadence.Value) (*big.Int, bool) {
+	if i, ok := bigIntegerOf(value); ok {
+		return new(big.Int).Set(i), true
+	}
+	switch v := value.(type) {
+	case cadence.Int8:
+		return big.NewInt(int64(v)), true
+	case cadence.Int16:
+		return big.NewInt(int64(v)), true
+	case cadence.Int32:
+		return big.NewInt(int64(v)), true
+	case cadence.Int64:
+		return big.NewInt(int64(v)), true
+	case cadence.UInt8:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case cadence.UInt16:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case cadence.UInt32:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case cadence.UInt64:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case cadence.Word8:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case cadence.Word16:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case cadence.Word32:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case cadence.Word64:
+		return new(big.Int).SetUint64(uint64(v)), true
+	}
+	return nil, false
+}
+
+// isGoIntegerKind. check if kind is go signed or unsigned integer.
+func isGoIntegerKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// setGoSigned. set signed value to go integer of any kind, with range checking.
+func setGoSigned(i int64, value cadence.Value, distV reflect.Value) error {
+	switch distV.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if distV.OverflowInt(i) {
+			return &OverflowError{Value: value.String(), CadenceType: value.Type(), GoType: distV.Type()}
+		}
+		distV.SetInt(i)
+		return nil
+	}
+	if i < 0 {
+		return &OverflowError{Value: value.String(), CadenceType: value.Type(), GoType: distV.Type()}
+	}
+	return setGoUnsigned(uint64(i), value, distV)
+}
+
+// setGoUnsigned. set unsigned value to go integer of any kind, with range checking.
+func setGoUnsigned(u uint64, value cadence.Value, distV reflect.Value) error {
+	switch distV.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if distV.OverflowUint(u) {
+			return &OverflowError{Value: value.String(), CadenceType: value.Type(), GoType: distV.Type()}
+		}
+		distV.SetUint(u)
+		return nil
+	}
+	if u > math.MaxInt64 {
+		return &OverflowError{Value: value.String(), CadenceType: value.Type(), GoType: distV.Type()}
+	}
+	return setGoSigned(int64(u), value, distV)
+}
+
+// toGoInteger. convert any cadence integer to go integer of any kind, including named types.
+// Return OverflowError if the value does not fit.
+// Fix64 and UFix64 can convert to int64 and uint64 kind, the raw value scaled by 1e8.
+func toGoInteger(value cadence.Value, distV reflect.Value) error {
+	if !isGoIntegerKind(distV.Kind()) {
+		return mismatchToGo(value, distV.Type())
+	}
+	switch v := value.(type) {
+	case cadence.Int8:
+		return setGoSigned(int64(v), value, distV)
+	case cadence.Int16:
+		return setGoSigned(int64(v), value, distV)
+	case cadence.Int32:
+		return setGoSigned(int64(v), value, distV)
+	case cadence.Int64:
+		return setGoSigned(int64(v), value, distV)
+	case cadence.UInt8:
+		return setGoUnsigned(uint64(v), value, distV)
+	case cadence.UInt16:
+		return setGoUnsigned(uint64(v), value, distV)
+	case cadence.UInt32:
+		return setGoUnsigned(uint64(v), value, distV)
+	case cadence.UInt64:
+		return setGoUnsigned(uint64(v), value, distV)
+	case cadence.Word8:
+		return setGoUnsigned(uint64(v), value, distV)
+	case cadence.Word16:
+		return setGoUnsigned(uint64(v), value, distV)
+	case cadence.Word32:
+		return setGoUnsigned(uint64(v), value, distV)
+	case cadence.Word64:
+		return setGoUnsigned(uint64(v), value, distV)
+	case cadence.Fix64:
+		if distV.Kind() == reflect.Int64 {
+			distV.SetInt(int64(v))
+			return nil
+		}
+	case cadence.UFix64:
+		if distV.Kind() == reflect.Uint64 {
+			distV.SetUint(uint64(v))
+			return nil
+		}
+	}
+	if i, ok := bigIntegerOf(value); ok {
+		switch {
+		case i.IsInt64():
+			return setGoSigned(i.Int64(), value, distV)
+		case i.IsUint64():
+			return setGoUnsigned(i.Uint64(), value, distV)
+		}
+		return &OverflowError{Value: value.String(), CadenceType: value.Type(), GoType: distV.Type()}
+	}
+	return mismatchToGo(value, distV.Type())
+}
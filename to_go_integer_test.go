@@ -0,0 +1,105 @@
+package godence
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToGoInteger(t *testing.T) {
+	t.Run("UInt8 to uint64", func(t *testing.T) {
+		assert := assert.New(t)
+		script := []byte(`pub fun main(): UInt8 { return 255 }`)
+
+		ret, err := flowCli.ExecuteScriptAtLatestBlock(context.Background(), script, nil)
+		assert.NoError(err)
+
+		var dist uint64
+		assert.NoError(ToGo(ret, &dist))
+		assert.Equal(uint64(255), dist)
+	})
+
+	t.Run("fits", func(t *testing.T) {
+		type score uint32
+		tests := []struct {
+			name  string
+			value cadence.Value
+			dist  any
+			want  any
+		}{
+			{name: "Int to int64", value: cadence.NewInt(-15), dist: new(int64), want: int64(-15)},
+			{name: "Int to int", value: cadence.NewInt(15), dist: new(int), want: 15},
+			{name: "UInt64 to uint", value: cadence.NewUInt64(15), dist: new(uint), want: uint(15)},
+			{name: "Int8 to uint8", value: cadence.NewInt8(15), dist: new(uint8), want: uint8(15)},
+			{name: "UInt256 to int16", value: cadence.NewUInt256(32767), dist: new(int16), want: int16(32767)},
+			{name: "Word64 to int64", value: cadence.NewWord64(15), dist: new(int64), want: int64(15)},
+			{name: "UInt32 to named type", value: cadence.NewUInt32(15), dist: new(score), want: score(15)},
+			{name: "Int8 to big.Int", value: cadence.NewInt8(-15), dist: new(*big.Int), want: big.NewInt(-15)},
+			{name: "UInt64 max to uint64", value: cadence.NewUInt64(1<<64 - 1), dist: new(uint64), want: uint64(1<<64 - 1)},
+			{name: "Int128 min int64 to int64", value: cadence.NewInt128(-1 << 63), dist: new(int64), want: int64(-1 << 63)},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert := assert.New(t)
+				assert.NoError(ToGo(tt.value, tt.dist))
+				// dist is a pointer of want
+				assert.Equal(tt.want, reflect.ValueOf(tt.dist).Elem().Interface())
+			})
+		}
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		tooBig, _ := new(big.Int).SetString("18446744073709551616", 10)
+		tests := []struct {
+			name    string
+			value   cadence.Value
+			dist    any
+			wantErr string
+		}{
+			{name: "Int16 to int8", value: cadence.NewInt16(128), dist: new(int8), wantErr: "value 128 out of range of int8"},
+			{name: "negative to uint", value: cadence.NewInt(-1), dist: new(uint), wantErr: "value -1 out of range of uint"},
+			{name: "negative to uint64", value: cadence.NewInt64(-1), dist: new(uint64), wantErr: "value -1 out of range of uint64"},
+			{name: "UInt64 to int64", value: cadence.NewUInt64(1 << 63), dist: new(int64), wantErr: "value 9223372036854775808 out of range of int64"},
+			{name: "UInt256 to uint64", value: cadence.UInt256{Value: tooBig}, dist: new(uint64), wantErr: "value 18446744073709551616 out of range of uint64"},
+			{name: "Word16 to uint8", value: cadence.NewWord16(256), dist: new(uint8), wantErr: "value 256 out of range of uint8"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert := assert.New(t)
+				err := ToGo(tt.value, tt.dist)
+				assert.EqualError(err, tt.wantErr)
+				assert.ErrorIs(err, ErrOverflow)
+			})
+		}
+	})
+
+	t.Run("overflow in struct field", func(t *testing.T) {
+		type person struct {
+			Age uint8 `godence:"age"`
+		}
+		assert := assert.New(t)
+		value := cadence.NewStruct([]cadence.Value{cadence.NewUInt64(256)}).WithType(&cadence.StructType{
+			QualifiedIdentifier: "Person",
+			Fields:              []cadence.Field{{Identifier: "age", Type: cadence.UInt64Type{}}},
+		})
+
+		err := ToGo(value, &person{})
+		assert.EqualError(err, "Person.age: value 256 out of range of uint8")
+
+		var overflowErr *OverflowError
+		assert.ErrorAs(err, &overflowErr)
+		assert.Equal("256", overflowErr.Value)
+		assert.Equal(cadence.UInt64Type{}, overflowErr.CadenceType)
+	})
+
+	t.Run("not an integer", func(t *testing.T) {
+		assert := assert.New(t)
+		var dist int
+		err := ToGo(cadence.UFix64(100000000), &dist)
+		assert.EqualError(err, "cannot convert UFix64 to int")
+	})
+}
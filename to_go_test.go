@@ -2,7 +2,9 @@ package godence
 
 import (
 	"context"
+	"fmt"
 	"math/big"
+	"reflect"
 	"testing"
 
 	"github.com/onflow/cadence"
@@ -18,11 +20,26 @@ func TestToGo(t *testing.T) {
 		assert.NoError(err)
 		assert.Equal(ret.Type().ID(), "Int")
 
-		dist := unsupportType("")
-		err = ToGo(ret, dist)
-		assert.EqualError(err, "unsupport type: godence.unsupportType")
+		dist := make(chan int)
+		err = ToGo(ret, &dist)
+		assert.EqualError(err, "unsupport type: *chan int")
+		assert.ErrorIs(err, ErrUnsupportedType)
 	})
-	t.Run("type cast failed", func(t *testing.T) {
+	t.Run("invalid destination", func(t *testing.T) {
+		assert := assert.New(t)
+
+		err := ToGo(cadence.NewInt(15), unsupportType(0))
+		assert.EqualError(err, "invalid destination godence.unsupportType, should be a non-nil pointer")
+		assert.ErrorIs(err, ErrInvalidDestination)
+
+		var nilPointer *string
+		err = ToGo(cadence.String("LemonNeko"), nilPointer)
+		assert.EqualError(err, "invalid destination *string, should be a non-nil pointer")
+
+		err = ToGo(cadence.String("LemonNeko"), nil)
+		assert.EqualError(err, "invalid destination <nil>, should be a non-nil pointer")
+	})
+	t.Run("integer overflow", func(t *testing.T) {
 		assert := assert.New(t)
 		script := []byte(`pub fun main(): Int16 { return 32767 }`)
 
@@ -32,7 +49,19 @@ func TestToGo(t *testing.T) {
 
 		var dist int8
 		err = ToGo(ret, &dist)
-		assert.EqualError(err, "panic recovered: interface conversion: interface {} is int16, not int8")
+		assert.EqualError(err, "value 32767 out of range of int8")
+		assert.ErrorIs(err, ErrOverflow)
+	})
+	t.Run("type cast failed", func(t *testing.T) {
+		assert := assert.New(t)
+		script := []byte(`pub fun main(): String { return "LemonNeko" }`)
+
+		ret, err := flowCli.ExecuteScriptAtLatestBlock(context.Background(), script, nil)
+		assert.NoError(err)
+
+		var dist int8
+		err = ToGo(ret, &dist)
+		assert.EqualError(err, "cannot convert String to int8")
 	})
 	// =============
 	// Optional
@@ -63,6 +92,18 @@ func TestToGo(t *testing.T) {
 		a.Nil(err)
 		a.Equal("", dist)
 	})
+	t.Run("Optional, pointer", func(t *testing.T) {
+		a := assert.New(t)
+
+		var dist *string
+		err := ToGo(cadence.NewOptional(cadence.String("Test")), &dist)
+		a.NoError(err)
+		a.Equal("Test", *dist)
+
+		err = ToGo(cadence.NewOptional(nil), &dist)
+		a.NoError(err)
+		a.Nil(dist)
+	})
 	// =============
 	// integers
 	// =============
@@ -526,8 +567,9 @@ pub fun main(): String {
 		assert.NoError(err)
 
 		dist := simpleStruct{}
-		err = toGoStruct(ret, &dist)
-		assert.EqualError(err, "to go struct: unsupport cadence type: cadence.String")
+		err = defaultDecoder.toGoStruct(ret, &dist)
+		assert.EqualError(err, "cannot convert String to godence.simpleStruct")
+		assert.ErrorIs(err, ErrTypeMismatch)
 	})
 
 	t.Run("a simple struct", func(t *testing.T) {
@@ -550,7 +592,7 @@ pub fun main(): SimpleStruct {
 		assert.NoError(err)
 
 		dist := simpleStruct{}
-		err = toGoStruct(ret, &dist)
+		err = defaultDecoder.toGoStruct(ret, &dist)
 		assert.NoError(err)
 		assert.Equal("LemonNeko", dist.MyName)
 	})
@@ -575,7 +617,7 @@ pub fun main(): SimpleStruct {
 		assert.NoError(err)
 
 		dist := simpleStruct{}
-		err = toGoStruct(ret, &dist)
+		err = defaultDecoder.toGoStruct(ret, &dist)
 		assert.NoError(err)
 		assert.Equal("", dist.myName)
 	})
@@ -600,7 +642,7 @@ pub fun main(): SimpleStruct {
 		assert.NoError(err)
 
 		dist := simpleStruct{}
-		err = toGoStruct(ret, &dist)
+		err = defaultDecoder.toGoStruct(ret, &dist)
 		assert.NoError(err)
 		assert.Equal("LemonNeko", dist.MyName)
 	})
@@ -625,8 +667,12 @@ pub fun main(): SimpleStruct {
 		assert.NoError(err)
 
 		dist := simpleStruct{}
-		err = toGoStruct(ret, &dist)
-		assert.EqualError(err, "cannot find field named none in cadence struct/event/resource")
+		err = defaultDecoder.toGoStruct(ret, &dist)
+		assert.ErrorIs(err, ErrFieldNotFound)
+		var fieldErr *FieldNotFoundError
+		assert.ErrorAs(err, &fieldErr)
+		assert.Equal("none", fieldErr.Field)
+		assert.Equal(reflect.TypeOf(dist), fieldErr.GoType)
 	})
 
 	t.Run("a simple struct, with wrong type", func(t *testing.T) {
@@ -649,8 +695,8 @@ pub fun main(): SimpleStruct {
 		assert.NoError(err)
 
 		dist := simpleStruct{}
-		err = toGoStruct(ret, &dist)
-		assert.EqualError(err, "structEventResourceToGoStruct, panic recoverd: reflect.Set: value of type uint8 is not assignable to type string")
+		err = defaultDecoder.toGoStruct(ret, &dist)
+		assert.EqualError(err, "myName: cannot convert UInt8 to string")
 	})
 
 	t.Run("a struct contains many type", func(t *testing.T) {
@@ -702,7 +748,7 @@ pub fun main(): StructContainsManyType {
 		assert.NoError(err)
 
 		dist := structContainsManyType{}
-		err = toGoStruct(ret, &dist)
+		err = defaultDecoder.toGoStruct(ret, &dist)
 		assert.NoError(err)
 		assert.Equal(big.NewInt(127), dist.IntValue)
 		assert.Equal(int8(127), dist.Int8Value)
@@ -753,7 +799,7 @@ pub fun main(): StructContainsManyType {
 		assert.NoError(err)
 
 		dist := embeddedStruct{}
-		err = toGoStruct(ret, &dist)
+		err = defaultDecoder.toGoStruct(ret, &dist)
 		assert.NoError(err)
 		assert.Equal("LemonNeko", dist.Inter.MyName)
 	})
@@ -782,7 +828,7 @@ transaction {
 		assert.Equal("A.f8d6e0586b0a20c7.ForTest.Simple", result.Events[0].Value.Type().ID())
 
 		dist := simpleStruct{}
-		err = toGoStruct(result.Events[0].Value, &dist)
+		err = defaultDecoder.toGoStruct(result.Events[0].Value, &dist)
 		assert.NoError(err)
 		assert.Equal("LemonNeko", dist.MyName)
 	})
@@ -811,7 +857,7 @@ transaction {
 		assert.Equal("A.f8d6e0586b0a20c7.ForTest.Simple2", result.Events[0].Value.Type().ID())
 
 		dist := simpleStruct{}
-		err = toGoStruct(result.Events[0].Value, &dist)
+		err = defaultDecoder.toGoStruct(result.Events[0].Value, &dist)
 		assert.NoError(err)
 		assert.Equal("LemonNeko", dist.MyName)
 	})
@@ -840,8 +886,8 @@ transaction {
 		assert.Equal("A.f8d6e0586b0a20c7.ForTest.Simple2", result.Events[0].Value.Type().ID())
 
 		dist := simpleStruct{}
-		err = toGoStruct(result.Events[0].Value, &dist)
-		assert.EqualError(err, "cannot find field named none in cadence struct/event/resource")
+		err = defaultDecoder.toGoStruct(result.Events[0].Value, &dist)
+		assert.EqualError(err, "cannot find field named none in A.f8d6e0586b0a20c7.ForTest.Simple2")
 	})
 
 	t.Run("a simple event, with wrong type", func(t *testing.T) {
@@ -868,8 +914,8 @@ transaction {
 		assert.Equal("A.f8d6e0586b0a20c7.ForTest.Simple3", result.Events[0].Value.Type().ID())
 
 		dist := simpleStruct{}
-		err = toGoStruct(result.Events[0].Value, &dist)
-		assert.EqualError(err, "structEventResourceToGoStruct, panic recoverd: reflect.Set: value of type uint8 is not assignable to type string")
+		err = defaultDecoder.toGoStruct(result.Events[0].Value, &dist)
+		assert.EqualError(err, "myName: cannot convert UInt8 to string")
 	})
 
 	t.Run("a event contains many type", func(t *testing.T) {
@@ -893,7 +939,7 @@ transaction {
 		assert.Equal("A.f8d6e0586b0a20c7.ForTest.ManyType", result.Events[0].Value.Type().ID())
 
 		dist := structContainsManyType{}
-		err = toGoStruct(result.Events[0].Value, &dist)
+		err = defaultDecoder.toGoStruct(result.Events[0].Value, &dist)
 		assert.NoError(err)
 		assert.Equal(big.NewInt(127), dist.IntValue)
 		assert.Equal(int8(127), dist.Int8Value)
@@ -940,7 +986,7 @@ transaction {
 		assert.Equal("A.f8d6e0586b0a20c7.ForTest.StructInParam", result.Events[0].Value.Type().ID())
 
 		dist := structInParam{}
-		err = toGoStruct(result.Events[0].Value, &dist)
+		err = defaultDecoder.toGoStruct(result.Events[0].Value, &dist)
 		assert.NoError(err)
 		assert.Equal("LemonNeko", dist.P.MyName)
 	})
@@ -960,7 +1006,7 @@ pub fun main(): &ForTest.SimpleR {
 		assert.NoError(err)
 
 		dist := simpleStruct{}
-		err = toGoStruct(ret, &dist)
+		err = defaultDecoder.toGoStruct(ret, &dist)
 		assert.NoError(err)
 		assert.Equal("LemonNeko", dist.MyName)
 	})
@@ -980,7 +1026,7 @@ pub fun main(): &ForTest.SimpleR2 {
 		assert.NoError(err)
 
 		dist := simpleStruct{}
-		err = toGoStruct(ret, &dist)
+		err = defaultDecoder.toGoStruct(ret, &dist)
 		assert.NoError(err)
 		assert.Equal("LemonNeko", dist.MyName)
 	})
@@ -1000,8 +1046,8 @@ pub fun main(): &ForTest.SimpleR2 {
 		assert.NoError(err)
 
 		dist := simpleStruct{}
-		err = toGoStruct(ret, &dist)
-		assert.EqualError(err, "cannot find field named none in cadence struct/event/resource")
+		err = defaultDecoder.toGoStruct(ret, &dist)
+		assert.EqualError(err, "cannot find field named none in A.f8d6e0586b0a20c7.ForTest.SimpleR2")
 	})
 
 	t.Run("a resource contains many type", func(t *testing.T) {
@@ -1016,7 +1062,7 @@ pub fun main(): &ForTest.ResourceContainsManyType {
 		assert.NoError(err)
 
 		dist := structContainsManyType{}
-		err = toGoStruct(ret, &dist)
+		err = defaultDecoder.toGoStruct(ret, &dist)
 		assert.NoError(err)
 		assert.Equal(big.NewInt(127), dist.IntValue)
 		assert.Equal(int8(127), dist.Int8Value)
@@ -1054,7 +1100,7 @@ pub fun main(): &ForTest.AStructEmbeddedInAResource {
 		assert.NoError(err)
 
 		dist := structInParam{}
-		err = toGoStruct(ret, &dist)
+		err = defaultDecoder.toGoStruct(ret, &dist)
 		assert.NoError(err)
 		assert.Equal("LemonNeko", dist.P.MyName)
 	})
@@ -1076,13 +1122,211 @@ pub fun main(): &ForTest.AResourceEmbeddedInAResource {
 		assert.NoError(err)
 
 		dist := structInParam{}
-		err = toGoStruct(ret, &dist)
+		err = defaultDecoder.toGoStruct(ret, &dist)
 		assert.NoError(err)
 		assert.Equal("LemonNeko", dist.P.MyName)
 	})
+
+	t.Run("optional pointer fields", func(t *testing.T) {
+		type person struct {
+			Nickname *string            `godence:"nickname"`
+			Age      *uint8             `godence:"age"`
+			Balance  *UFix64            `godence:"balance"`
+			Scores   []*uint8           `godence:"scores"`
+			Tags     map[string]*string `godence:"tags"`
+		}
+		assert := assert.New(t)
+		script := []byte(`
+pub struct Person {
+	pub var nickname: String?
+	pub var age: UInt8?
+	pub var balance: UFix64?
+	pub var scores: [UInt8?]
+	pub var tags: {String: String?}
+
+	init() {
+		self.nickname = "LemonNeko"
+		self.age = nil
+		self.balance = 0.5
+		self.scores = [1, nil]
+		self.tags = {"cat": nil}
+	}
+}
+pub fun main(): Person {
+	return Person()
+}`)
+		ret, err := flowCli.ExecuteScriptAtLatestBlock(context.Background(), script, nil)
+		assert.NoError(err)
+
+		age := uint8(18)
+		dist := person{Age: &age}
+		err = defaultDecoder.toGoStruct(ret, &dist)
+		assert.NoError(err)
+		assert.Equal("LemonNeko", *dist.Nickname)
+		assert.Nil(dist.Age)
+		assert.Equal(UFix64(50000000), *dist.Balance)
+		assert.Equal(uint8(1), *dist.Scores[0])
+		assert.Nil(dist.Scores[1])
+		assert.Contains(dist.Tags, "cat")
+		assert.Nil(dist.Tags["cat"])
+	})
+
+	t.Run("optional pointer fields, constructed", func(t *testing.T) {
+		type person struct {
+			Nickname *string `godence:"nickname"`
+			Age      *uint8  `godence:"age"`
+		}
+		assert := assert.New(t)
+		value := cadence.NewStruct([]cadence.Value{
+			cadence.NewOptional(cadence.String("LemonNeko")),
+			cadence.NewOptional(nil),
+		}).WithType(&cadence.StructType{
+			QualifiedIdentifier: "Person",
+			Fields: []cadence.Field{
+				{Identifier: "nickname", Type: cadence.OptionalType{Type: cadence.StringType{}}},
+				{Identifier: "age", Type: cadence.OptionalType{Type: cadence.UInt8Type{}}},
+			},
+		})
+
+		age := uint8(18)
+		dist := person{Age: &age}
+		err := defaultDecoder.toGoStruct(value, &dist)
+		assert.NoError(err)
+		assert.Equal("LemonNeko", *dist.Nickname)
+		assert.Nil(dist.Age)
+	})
+
+	t.Run("optional pointer fields, type mismatched", func(t *testing.T) {
+		type person struct {
+			Age *uint8 `godence:"age"`
+		}
+		assert := assert.New(t)
+		value := cadence.NewStruct([]cadence.Value{
+			cadence.NewOptional(cadence.String("LemonNeko")),
+		}).WithType(&cadence.StructType{
+			QualifiedIdentifier: "Person",
+			Fields:              []cadence.Field{{Identifier: "age", Type: cadence.OptionalType{Type: cadence.StringType{}}}},
+		})
+
+		dist := person{}
+		err := defaultDecoder.toGoStruct(value, &dist)
+		assert.EqualError(err, "age: cannot convert String to uint8")
+	})
+
+	t.Run("embedded struct error has full path", func(t *testing.T) {
+		type structInParam struct {
+			P struct {
+				MyName uint8 `godence:"myName"`
+			} `godence:"p"`
+		}
+		assert := assert.New(t)
+		forEmbeddedType := &cadence.StructType{
+			QualifiedIdentifier: "ForTest.ForEmbedded",
+			Fields:              []cadence.Field{{Identifier: "myName", Type: cadence.StringType{}}},
+		}
+		value := cadence.NewEvent([]cadence.Value{
+			cadence.NewStruct([]cadence.Value{cadence.String("LemonNeko")}).WithType(forEmbeddedType),
+		}).WithType(&cadence.EventType{
+			QualifiedIdentifier: "ForTest.StructInParam",
+			Fields:              []cadence.Field{{Identifier: "p", Type: forEmbeddedType}},
+		})
+
+		dist := structInParam{}
+		err := ToGo(value, &dist)
+		assert.EqualError(err, "StructInParam.p.myName: cannot convert String to uint8")
+		var mismatchErr *TypeMismatchError
+		assert.ErrorAs(err, &mismatchErr)
+		assert.Equal("StructInParam.p.myName", mismatchErr.Path)
+	})
+
+	t.Run("embedded struct in slice error has full path", func(t *testing.T) {
+		type structInParam struct {
+			P []*struct {
+				MyName uint8 `godence:"myName"`
+			} `godence:"p"`
+		}
+		assert := assert.New(t)
+		forEmbeddedType := &cadence.StructType{
+			QualifiedIdentifier: "ForTest.ForEmbedded",
+			Fields:              []cadence.Field{{Identifier: "myName", Type: cadence.StringType{}}},
+		}
+		value := cadence.NewStruct([]cadence.Value{
+			cadence.NewArray([]cadence.Value{
+				cadence.NewStruct([]cadence.Value{cadence.String("LemonNeko")}).WithType(forEmbeddedType),
+			}),
+		}).WithType(&cadence.StructType{
+			QualifiedIdentifier: "Outer",
+			Fields:              []cadence.Field{{Identifier: "p", Type: cadence.VariableSizedArrayType{ElementType: forEmbeddedType}}},
+		})
+
+		dist := structInParam{}
+		err := ToGo(value, &dist)
+		assert.EqualError(err, "Outer.p[0].myName: cannot convert String to uint8")
+	})
+
+	t.Run("a event with wrong type in embedded struct", func(t *testing.T) {
+		type structInParam struct {
+			P struct {
+				MyName uint8 `godence:"myName"`
+			} `godence:"p"`
+		}
+		assert := assert.New(t)
+		script := []byte(`
+import ForTest from 0xf8d6e0586b0a20c7
+
+transaction {
+	prepare(acct: AuthAccount) {}
+	execute {
+		ForTest.emitStructInParam()
+	}
+}
+		`)
+		tx := buildSimpleTx(script, assert)
+		err := flowCli.SendTransaction(context.Background(), *tx)
+		assert.NoError(err)
+
+		result := waitForTransactionSealed(tx, assert)
+		assert.NoError(result.Error)
+		assert.Equal(1, len(result.Events))
+
+		dist := structInParam{}
+		err = ToGo(result.Events[0].Value, &dist)
+		assert.EqualError(err, "StructInParam.p.myName: cannot convert String to uint8")
+	})
+
+	t.Run("interface field", func(t *testing.T) {
+		type simpleStruct struct {
+			MyName any `godence:"myName"`
+		}
+		assert := assert.New(t)
+		value := cadence.NewStruct([]cadence.Value{cadence.String("LemonNeko")}).WithType(&cadence.StructType{
+			QualifiedIdentifier: "SimpleStruct",
+			Fields:              []cadence.Field{{Identifier: "myName", Type: cadence.StringType{}}},
+		})
+
+		dist := simpleStruct{}
+		err := ToGo(value, &dist)
+		assert.NoError(err)
+		assert.Equal("LemonNeko", dist.MyName)
+	})
 }
 
 func TestToGoMap(t *testing.T) {
+	t.Run("nil to a map", func(t *testing.T) {
+		assert := assert.New(t)
+
+		// map is not a pointer, entries are kept
+		dist := map[string]int{"LemonNeko": 1}
+		assert.NoError(ToGo(cadence.NewOptional(nil), dist))
+		assert.Equal(map[string]int{"LemonNeko": 1}, dist)
+		assert.NoError(ToGo(cadence.NewVoid(), dist))
+		assert.Equal(map[string]int{"LemonNeko": 1}, dist)
+
+		// pointer to map is set to nil
+		assert.NoError(ToGo(cadence.NewOptional(nil), &dist))
+		assert.Nil(dist)
+	})
+
 	t.Run("a string string dictionary", func(t *testing.T) {
 		assert := assert.New(t)
 		defer func() {
@@ -1100,7 +1344,7 @@ pub fun main(): {String: String} {
 		assert.NoError(err)
 
 		dist := map[string]string{}
-		err = toGoMap(ret, dist)
+		err = defaultDecoder.toGoMap(ret, dist)
 		assert.NoError(err)
 		assert.Equal("LemonNeko", dist["MyName"])
 	})
@@ -1122,7 +1366,7 @@ pub fun main(): {Int64: Int64} {
 		assert.NoError(err)
 
 		dist := map[int64]int64{}
-		err = toGoMap(ret, dist)
+		err = defaultDecoder.toGoMap(ret, dist)
 		assert.NoError(err)
 		assert.Equal(int64(64), dist[88])
 	})
@@ -1142,8 +1386,98 @@ pub fun main(): {String: String} {
 		assert.NoError(err)
 
 		dist := map[uint64]uint64{}
-		err = toGoMap(ret, dist)
-		assert.EqualError(err, "toGoMap, panic recoverd: reflect.Value.SetMapIndex: value of type string is not assignable to type uint64")
+		err = defaultDecoder.toGoMap(ret, dist)
+		assert.EqualError(err, `[key "MyName"]: cannot convert String to uint64`)
+	})
+
+	t.Run("value type mismatched", func(t *testing.T) {
+		assert := assert.New(t)
+		ret := cadence.NewDictionary([]cadence.KeyValuePair{
+			{Key: cadence.String("MyName"), Value: cadence.String("LemonNeko")},
+		})
+
+		dist := map[string]uint64{}
+		err := defaultDecoder.toGoMap(ret, dist)
+		assert.EqualError(err, `["MyName"]: cannot convert String to uint64`)
+	})
+
+	t.Run("an address ufix64 dictionary", func(t *testing.T) {
+		assert := assert.New(t)
+		script := []byte(`
+pub fun main(): {Address: UFix64} {
+	return {
+		0xf8d6e0586b0a20c7: 0.5
+	}
+}`)
+		ret, err := flowCli.ExecuteScriptAtLatestBlock(context.Background(), script, nil)
+		assert.NoError(err)
+
+		dist := map[string]uint64{}
+		err = defaultDecoder.toGoMap(ret, dist)
+		assert.NoError(err)
+		assert.Equal(map[string]uint64{"0xf8d6e0586b0a20c7": 50000000}, dist)
+
+		helpers := map[Address]UFix64{}
+		err = ToGo(ret, &helpers)
+		assert.NoError(err)
+		assert.Equal(map[Address]UFix64{"0xf8d6e0586b0a20c7": 50000000}, helpers)
+	})
+
+	t.Run("a string struct dictionary", func(t *testing.T) {
+		type forEmbedded struct {
+			MyName string `godence:"myName"`
+		}
+		assert := assert.New(t)
+		script := []byte(`
+import ForTest from 0xf8d6e0586b0a20c7
+
+pub fun main(): {String: ForTest.ForEmbedded} {
+	return {"LemonNeko": ForTest.ForEmbedded()}
+}`)
+		ret, err := flowCli.ExecuteScriptAtLatestBlock(context.Background(), script, nil)
+		assert.NoError(err)
+
+		dist := map[string]forEmbedded{}
+		err = defaultDecoder.toGoMap(ret, dist)
+		assert.NoError(err)
+		assert.Equal(map[string]forEmbedded{"LemonNeko": {MyName: "LemonNeko"}}, dist)
+	})
+
+	t.Run("a nested dictionary", func(t *testing.T) {
+		assert := assert.New(t)
+		ret := cadence.NewDictionary([]cadence.KeyValuePair{{
+			Key: cadence.String("LemonNeko"),
+			Value: cadence.NewDictionary([]cadence.KeyValuePair{
+				{Key: cadence.NewInt64(88), Value: cadence.NewArray([]cadence.Value{cadence.NewInt64(64)})},
+			}),
+		}})
+
+		dist := map[string]map[int64][]int64{}
+		err := defaultDecoder.toGoMap(ret, dist)
+		assert.NoError(err)
+		assert.Equal(map[string]map[int64][]int64{"LemonNeko": {88: {64}}}, dist)
+	})
+
+	t.Run("pointer to nil map", func(t *testing.T) {
+		assert := assert.New(t)
+		ret := cadence.NewOptional(cadence.NewDictionary([]cadence.KeyValuePair{
+			{Key: cadence.String("MyName"), Value: cadence.String("LemonNeko")},
+		}))
+
+		var dist map[string]string
+		err := ToGo(ret, &dist)
+		assert.NoError(err)
+		assert.Equal(map[string]string{"MyName": "LemonNeko"}, dist)
+	})
+
+	t.Run("nil map", func(t *testing.T) {
+		assert := assert.New(t)
+		ret := cadence.NewDictionary([]cadence.KeyValuePair{})
+
+		var dist map[string]string
+		err := defaultDecoder.toGoMap(ret, dist)
+		assert.EqualError(err, "cannot set entries to nil map map[string]string, use pointer to map instead")
+		assert.ErrorIs(err, ErrInvalidDestination)
 	})
 
 	t.Run("not a dictionary", func(t *testing.T) {
@@ -1161,8 +1495,8 @@ pub fun main(): String {
 		assert.NoError(err)
 
 		dist := map[string]string{}
-		err = toGoMap(ret, dist)
-		assert.EqualError(err, "toGoMap, panic recoverd: interface conversion: cadence.Value is cadence.String, not cadence.Dictionary")
+		err = defaultDecoder.toGoMap(ret, dist)
+		assert.EqualError(err, "cannot convert String to map[string]string")
 	})
 }
 
@@ -1177,7 +1511,7 @@ pub fun main(): [String] {
 		assert.NoError(err)
 
 		dist := []string{}
-		err = toGoSlice(ret, &dist)
+		err = defaultDecoder.toGoSlice(ret, &dist)
 		assert.NoError(err)
 		assert.Equal([]string{"MyName", "LemonNeko"}, dist)
 	})
@@ -1192,7 +1526,7 @@ pub fun main(): [Int64] {
 		assert.NoError(err)
 
 		dist := []int64{}
-		err = toGoSlice(ret, &dist)
+		err = defaultDecoder.toGoSlice(ret, &dist)
 		assert.NoError(err)
 		assert.Equal([]int64{88, 64}, dist)
 	})
@@ -1207,8 +1541,111 @@ pub fun main(): [String] {
 		assert.NoError(err)
 
 		dist := []uint64{}
-		err = toGoSlice(ret, &dist)
-		assert.EqualError(err, "toGoSlice, panic recoverd: reflect.Set: value of type string is not assignable to type uint64")
+		err = defaultDecoder.toGoSlice(ret, &dist)
+		assert.EqualError(err, "[0]: cannot convert String to uint64")
+	})
+
+	t.Run("a struct slice", func(t *testing.T) {
+		type forEmbedded struct {
+			MyName string `godence:"myName"`
+		}
+		assert := assert.New(t)
+		script := []byte(`
+import ForTest from 0xf8d6e0586b0a20c7
+
+pub fun main(): [ForTest.ForEmbedded] {
+	return [ForTest.ForEmbedded(), ForTest.ForEmbedded()]
+}`)
+		ret, err := flowCli.ExecuteScriptAtLatestBlock(context.Background(), script, nil)
+		assert.NoError(err)
+
+		dist := []forEmbedded{}
+		err = defaultDecoder.toGoSlice(ret, &dist)
+		assert.NoError(err)
+		assert.Equal([]forEmbedded{{MyName: "LemonNeko"}, {MyName: "LemonNeko"}}, dist)
+
+		pointers := []*forEmbedded{}
+		err = defaultDecoder.toGoSlice(ret, &pointers)
+		assert.NoError(err)
+		assert.Equal([]*forEmbedded{{MyName: "LemonNeko"}, {MyName: "LemonNeko"}}, pointers)
+	})
+
+	t.Run("an address slice", func(t *testing.T) {
+		assert := assert.New(t)
+		ret := cadence.NewArray([]cadence.Value{
+			cadence.BytesToAddress([]byte{0xf8, 0xd6, 0xe0, 0x58, 0x6b, 0x0a, 0x20, 0xc7}),
+		})
+
+		dist := []string{}
+		err := defaultDecoder.toGoSlice(ret, &dist)
+		assert.NoError(err)
+		assert.Equal([]string{"0xf8d6e0586b0a20c7"}, dist)
+	})
+
+	t.Run("a nested slice", func(t *testing.T) {
+		assert := assert.New(t)
+		ret := cadence.NewArray([]cadence.Value{
+			cadence.NewArray([]cadence.Value{cadence.NewInt64(88), cadence.NewInt64(64)}),
+			cadence.NewArray([]cadence.Value{}),
+		})
+
+		dist := [][]int64{}
+		err := defaultDecoder.toGoSlice(ret, &dist)
+		assert.NoError(err)
+		assert.Equal([][]int64{{88, 64}, {}}, dist)
+	})
+
+	t.Run("an optional slice", func(t *testing.T) {
+		assert := assert.New(t)
+		ret := cadence.NewOptional(cadence.NewArray([]cadence.Value{
+			cadence.NewOptional(cadence.String("LemonNeko")),
+			cadence.NewOptional(nil),
+		}))
+
+		dist := []*string{}
+		err := defaultDecoder.toGoSlice(ret, &dist)
+		assert.NoError(err)
+		name := "LemonNeko"
+		assert.Equal([]*string{&name, nil}, dist)
+	})
+
+	t.Run("reuse a slice", func(t *testing.T) {
+		type event struct {
+			ID   uint64            `godence:"id"`
+			Tags map[string]string `godence:"tags,optional"`
+		}
+		assert := assert.New(t)
+
+		dist := []int{}
+		assert.NoError(ToGo(cadence.NewArray([]cadence.Value{cadence.NewInt(1)}), &dist))
+		assert.NoError(ToGo(cadence.NewArray([]cadence.Value{cadence.NewInt(2)}), &dist))
+		assert.Equal([]int{2}, dist)
+		assert.NoError(ToGo(cadence.NewArray([]cadence.Value{}), &dist))
+		assert.Equal([]int{}, dist)
+
+		// elements are not merged with the previous ones
+		structType := &cadence.StructType{QualifiedIdentifier: "Event", Fields: []cadence.Field{{Identifier: "id", Type: cadence.UInt64Type{}}}}
+		events := []event{{ID: 1, Tags: map[string]string{"old": "true"}}, {ID: 2}}
+		assert.NoError(ToGo(cadence.NewArray([]cadence.Value{cadence.NewStruct([]cadence.Value{cadence.NewUInt64(3)}).WithType(structType)}), &events))
+		assert.Equal([]event{{ID: 3}}, events)
+	})
+
+	t.Run("a struct slice, field type mismatched", func(t *testing.T) {
+		type forEmbedded struct {
+			MyName uint8 `godence:"myName"`
+		}
+		assert := assert.New(t)
+		structType := &cadence.StructType{
+			QualifiedIdentifier: "ForEmbedded",
+			Fields:              []cadence.Field{{Identifier: "myName", Type: cadence.StringType{}}},
+		}
+		ret := cadence.NewArray([]cadence.Value{
+			cadence.NewStruct([]cadence.Value{cadence.String("LemonNeko")}).WithType(structType),
+		})
+
+		dist := []forEmbedded{}
+		err := defaultDecoder.toGoSlice(ret, &dist)
+		assert.EqualError(err, "[0].myName: cannot convert String to uint8")
 	})
 
 	t.Run("not a slice", func(t *testing.T) {
@@ -1222,8 +1659,112 @@ pub fun main(): String {
 		assert.NoError(err)
 
 		dist := []string{}
-		err = toGoSlice(ret, &dist)
-		assert.EqualError(err, "toGoSlice, panic recoverd: interface conversion: cadence.Value is cadence.String, not cadence.Array")
+		err = defaultDecoder.toGoSlice(ret, &dist)
+		assert.EqualError(err, "cannot convert String to []string")
+	})
+}
+
+func TestToGoArray(t *testing.T) {
+	intArray := func(values ...int) cadence.Array {
+		elements := []cadence.Value{}
+		for _, value := range values {
+			elements = append(elements, cadence.NewInt(value))
+		}
+		return cadence.NewArray(elements).WithType(cadence.VariableSizedArrayType{ElementType: cadence.IntType{}})
+	}
+
+	t.Run("a int array", func(t *testing.T) {
+		assert := assert.New(t)
+
+		dist := [2]int{}
+		assert.NoError(ToGo(intArray(88, 64), &dist))
+		assert.Equal([2]int{88, 64}, dist)
+	})
+
+	t.Run("an optional array of pointers", func(t *testing.T) {
+		assert := assert.New(t)
+		ret := cadence.NewOptional(cadence.NewArray([]cadence.Value{
+			cadence.NewOptional(cadence.String("LemonNeko")),
+			cadence.NewOptional(nil),
+		}))
+
+		dist := [2]*string{}
+		assert.NoError(ToGo(ret, &dist))
+		name := "LemonNeko"
+		assert.Equal([2]*string{&name, nil}, dist)
+	})
+
+	t.Run("a nested array in struct", func(t *testing.T) {
+		type matrix struct {
+			Cells [2][2]int `godence:"cells"`
+		}
+		assert := assert.New(t)
+		ret := cadence.NewStruct([]cadence.Value{
+			cadence.NewArray([]cadence.Value{intArray(1, 2), intArray(3, 4)}),
+		}).WithType(&cadence.StructType{
+			QualifiedIdentifier: "Matrix",
+			Fields:              []cadence.Field{{Identifier: "cells", Type: cadence.VariableSizedArrayType{ElementType: cadence.VariableSizedArrayType{ElementType: cadence.IntType{}}}}},
+		})
+
+		dist := matrix{}
+		assert.NoError(ToGo(ret, &dist))
+		assert.Equal(matrix{Cells: [2][2]int{{1, 2}, {3, 4}}}, dist)
+
+		// round trip
+		cadenceValue, err := ToCadenceAs(dist.Cells, ret.StructType.Fields[0].Type)
+		assert.NoError(err)
+		assert.Equal(ret.Fields[0].(cadence.Array).Values, cadenceValue.(cadence.Array).Values)
+	})
+
+	t.Run("length mismatched", func(t *testing.T) {
+		assert := assert.New(t)
+
+		dist := [2]int{}
+		err := ToGo(intArray(1, 2, 3), &dist)
+		assert.EqualError(err, "length 3: cannot convert [Int] to [2]int")
+		assert.ErrorIs(err, ErrTypeMismatch)
+
+		matrix := [2][2]int{}
+		err = ToGo(cadence.NewArray([]cadence.Value{intArray(1, 2), intArray(3)}), &matrix)
+		assert.EqualError(err, "[1]: length 1: cannot convert [Int] to [2]int")
+	})
+
+	t.Run("a byte array of address size", func(t *testing.T) {
+		assert := assert.New(t)
+		values := []cadence.Value{}
+		for i := 1; i <= 8; i++ {
+			values = append(values, cadence.NewUInt8(uint8(i)))
+		}
+
+		dist := [8]uint8{}
+		assert.NoError(ToGo(cadence.NewArray(values), &dist))
+		assert.Equal([8]uint8{1, 2, 3, 4, 5, 6, 7, 8}, dist)
+
+		// address is still supported
+		assert.NoError(ToGo(cadence.NewAddress([8]uint8{0xf8, 0xd6, 0xe0, 0x58, 0x6b, 0x0a, 0x20, 0xc7}), &dist))
+		assert.Equal([8]uint8{0xf8, 0xd6, 0xe0, 0x58, 0x6b, 0x0a, 0x20, 0xc7}, dist)
+
+		// untyped array is reported by its go type
+		err := ToGo(cadence.NewArray(values[:4]), &dist)
+		assert.EqualError(err, "length 4: cannot convert cadence.Array to [8]uint8")
+		err = ToGo(cadence.String("LemonNeko"), &dist)
+		assert.EqualError(err, "cannot convert String to [8]uint8")
+	})
+
+	t.Run("element type mismatched", func(t *testing.T) {
+		assert := assert.New(t)
+
+		dist := [1]uint8{}
+		err := ToGo(cadence.NewArray([]cadence.Value{cadence.String("LemonNeko")}), &dist)
+		assert.EqualError(err, "[0]: cannot convert String to uint8")
+	})
+
+	t.Run("not an array", func(t *testing.T) {
+		assert := assert.New(t)
+
+		dist := [1]string{}
+		err := ToGo(cadence.String("LemonNeko"), &dist)
+		assert.EqualError(err, "cannot convert String to [1]string")
 	})
 }
 
@@ -1263,3 +1804,317 @@ func Test_isValueAddressOrPath(t *testing.T) {
 		})
 	}
 }
+
+// ToGo(ToCadence(x)) should be x for every helper type.
+func TestHelperTypesRoundTrip(t *testing.T) {
+	type helpers struct {
+		UFix64    UFix64
+		Fix64     Fix64
+		Address   Address
+		Path      Path
+		Character Character
+	}
+	expect := helpers{
+		UFix64:    UFix64(50000000),
+		Fix64:     Fix64(-50000000),
+		Address:   Address("0xf8d6e0586b0a20c7"),
+		Path:      Path("/public/simpleR"),
+		Character: Character("L"),
+	}
+
+	t.Run("UFix64", func(t *testing.T) {
+		assert := assert.New(t)
+		cadenceValue, err := ToCadence(expect.UFix64)
+		assert.NoError(err)
+		var dist UFix64
+		assert.NoError(ToGo(cadenceValue, &dist))
+		assert.Equal(expect.UFix64, dist)
+	})
+	t.Run("Fix64", func(t *testing.T) {
+		assert := assert.New(t)
+		cadenceValue, err := ToCadence(expect.Fix64)
+		assert.NoError(err)
+		var dist Fix64
+		assert.NoError(ToGo(cadenceValue, &dist))
+		assert.Equal(expect.Fix64, dist)
+	})
+	t.Run("Address", func(t *testing.T) {
+		assert := assert.New(t)
+		cadenceValue, err := ToCadence(expect.Address)
+		assert.NoError(err)
+		var dist Address
+		assert.NoError(ToGo(cadenceValue, &dist))
+		assert.Equal(expect.Address, dist)
+	})
+	t.Run("Path", func(t *testing.T) {
+		assert := assert.New(t)
+		cadenceValue, err := ToCadence(expect.Path)
+		assert.NoError(err)
+		var dist Path
+		assert.NoError(ToGo(cadenceValue, &dist))
+		assert.Equal(expect.Path, dist)
+	})
+	t.Run("Character", func(t *testing.T) {
+		assert := assert.New(t)
+		cadenceValue, err := ToCadence(expect.Character)
+		assert.NoError(err)
+		var dist Character
+		assert.NoError(ToGo(cadenceValue, &dist))
+		assert.Equal(expect.Character, dist)
+	})
+	t.Run("struct fields", func(t *testing.T) {
+		assert := assert.New(t)
+		err := RegisterStruct("A.f8d6e0586b0a20c7.ForTest.Helpers", helpers{})
+		assert.NoError(err)
+		cadenceValue, err := ToCadence(expect)
+		assert.NoError(err)
+
+		dist := helpers{}
+		assert.NoError(ToGo(cadenceValue, &dist))
+		assert.Equal(expect, dist)
+	})
+	t.Run("UFix64 from script", func(t *testing.T) {
+		assert := assert.New(t)
+		script := []byte(`pub fun main(): UFix64 { return 0.5 }`)
+
+		ret, err := flowCli.ExecuteScriptAtLatestBlock(context.Background(), script, nil)
+		assert.NoError(err)
+
+		var dist UFix64
+		assert.NoError(ToGo(ret, &dist))
+		assert.Equal(expect.UFix64, dist)
+	})
+}
+
+func TestToGoPrimitives(t *testing.T) {
+	values := []cadence.Value{
+		cadence.NewInt(1),
+		cadence.NewInt8(1),
+		cadence.NewInt16(1),
+		cadence.NewInt32(1),
+		cadence.NewInt64(1),
+		cadence.NewInt128(1),
+		cadence.NewInt256(1),
+		cadence.NewUInt(1),
+		cadence.NewUInt8(1),
+		cadence.NewUInt16(1),
+		cadence.NewUInt32(1),
+		cadence.NewUInt64(1),
+		cadence.NewUInt128(1),
+		cadence.NewUInt256(1),
+		cadence.NewWord8(1),
+		cadence.NewWord16(1),
+		cadence.NewWord32(1),
+		cadence.NewWord64(1),
+		cadence.Fix64(100000000),
+		cadence.UFix64(100000000),
+		cadence.String("LemonNeko"),
+		cadence.Character("L"),
+		cadence.NewAddress([8]uint8{0xf8, 0xd6, 0xe0, 0x58, 0x6b, 0x0a, 0x20, 0xc7}),
+		cadence.NewPath("public", "simpleR"),
+		cadence.NewBool(true),
+	}
+	integers := []string{
+		"Int", "Int8", "Int16", "Int32", "Int64", "Int128", "Int256",
+		"UInt", "UInt8", "UInt16", "UInt32", "UInt64", "UInt128", "UInt256",
+		"Word8", "Word16", "Word32", "Word64",
+	}
+	// destination -> cadence type ids can be converted to it
+	dists := []struct {
+		name    string
+		dist    func() any
+		accepts []string
+	}{
+		{name: "*big.Int", dist: func() any { return new(*big.Int) }, accepts: integers},
+		{name: "int", dist: func() any { return new(int) }, accepts: integers},
+		{name: "int8", dist: func() any { return new(int8) }, accepts: integers},
+		{name: "int16", dist: func() any { return new(int16) }, accepts: integers},
+		{name: "int32", dist: func() any { return new(int32) }, accepts: integers},
+		{name: "int64", dist: func() any { return new(int64) }, accepts: append([]string{"Fix64"}, integers...)},
+		{name: "uint", dist: func() any { return new(uint) }, accepts: integers},
+		{name: "uint8", dist: func() any { return new(uint8) }, accepts: integers},
+		{name: "uint16", dist: func() any { return new(uint16) }, accepts: integers},
+		{name: "uint32", dist: func() any { return new(uint32) }, accepts: integers},
+		{name: "uint64", dist: func() any { return new(uint64) }, accepts: append([]string{"UFix64"}, integers...)},
+		{name: "Fix64", dist: func() any { return new(Fix64) }, accepts: []string{"Fix64"}},
+		{name: "UFix64", dist: func() any { return new(UFix64) }, accepts: []string{"UFix64"}},
+		{name: "float32", dist: func() any { return new(float32) }, accepts: []string{"Fix64", "UFix64"}},
+		{name: "float64", dist: func() any { return new(float64) }, accepts: []string{"Fix64", "UFix64"}},
+		{name: "string", dist: func() any { return new(string) }, accepts: []string{"String", "Character", "Address", "Path"}},
+		{name: "[8]uint8", dist: func() any { return new([8]uint8) }, accepts: []string{"Address"}},
+		{name: "cadence.Address", dist: func() any { return new(cadence.Address) }, accepts: []string{"Address"}},
+		{name: "Address", dist: func() any { return new(Address) }, accepts: []string{"Address"}},
+		{name: "Path", dist: func() any { return new(Path) }, accepts: []string{"Path"}},
+		{name: "Character", dist: func() any { return new(Character) }, accepts: []string{"Character"}},
+		{name: "bool", dist: func() any { return new(bool) }, accepts: []string{"Bool"}},
+	}
+	for _, d := range dists {
+		for _, value := range values {
+			d, value := d, value
+			t.Run(value.Type().ID()+" to "+d.name, func(t *testing.T) {
+				assert := assert.New(t)
+				accepted := false
+				for _, id := range d.accepts {
+					accepted = accepted || id == value.Type().ID()
+				}
+
+				err := ToGo(value, d.dist())
+				if accepted {
+					assert.NoError(err)
+					return
+				}
+				assert.ErrorIs(err, ErrTypeMismatch)
+				assert.NotContains(err.Error(), "panic")
+			})
+		}
+	}
+
+	t.Run("any", func(t *testing.T) {
+		for _, value := range values {
+			assert := assert.New(t)
+			var dist any
+			assert.NoError(ToGo(value, &dist))
+			assert.Equal(value.ToGoValue(), dist)
+		}
+	})
+
+	t.Run("optional", func(t *testing.T) {
+		assert := assert.New(t)
+		var dist int8
+		assert.NoError(ToGo(cadence.NewOptional(cadence.NewInt8(1)), &dist))
+		assert.Equal(int8(1), dist)
+
+		err := ToGo(cadence.NewOptional(cadence.String("LemonNeko")), &dist)
+		assert.EqualError(err, "cannot convert String to int8")
+	})
+}
+
+// benchmarkNested. struct decoded from a deeply nested cadence struct.
+type benchmarkNested struct {
+	Value uint64           `godence:"value"`
+	Child *benchmarkNested `godence:"child"`
+}
+
+// nestedCadenceStruct. cadence struct nested in optional field child, depth levels.
+func nestedCadenceStruct(depth int) cadence.Value {
+	typ := &cadence.StructType{QualifiedIdentifier: "Nested"}
+	typ.Fields = []cadence.Field{
+		{Identifier: "value", Type: cadence.UInt64Type{}},
+		{Identifier: "child", Type: cadence.OptionalType{Type: typ}},
+	}
+	child := cadence.NewOptional(nil)
+	for i := 0; i < depth; i++ {
+		child = cadence.NewOptional(cadence.NewStruct([]cadence.Value{cadence.NewUInt64(uint64(i)), child}).WithType(typ))
+	}
+	return child.Value
+}
+
+func BenchmarkToGo(b *testing.B) {
+	b.Run("array of 10k UInt64", func(b *testing.B) {
+		values := make([]cadence.Value, 10000)
+		for i := range values {
+			values[i] = cadence.NewUInt64(uint64(i))
+		}
+		value := cadence.NewArray(values)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			var dist []uint64
+			if err := ToGo(value, &dist); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("dictionary of 10k String:UInt64", func(b *testing.B) {
+		pairs := make([]cadence.KeyValuePair, 10000)
+		for i := range pairs {
+			pairs[i] = cadence.KeyValuePair{Key: cadence.String(fmt.Sprint(i)), Value: cadence.NewUInt64(uint64(i))}
+		}
+		value := cadence.NewDictionary(pairs)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			var dist map[string]uint64
+			if err := ToGo(value, &dist); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("struct nested 100 levels", func(b *testing.B) {
+		value := nestedCadenceStruct(100)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			dist := benchmarkNested{}
+			if err := ToGo(value, &dist); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestNamedTypesToGo(t *testing.T) {
+	type username string
+	type score uint32
+	type price float64
+	type flag bool
+	type profile struct {
+		Name   username   `godence:"name"`
+		Score  score      `godence:"score"`
+		Price  *price     `godence:"price"`
+		Active flag       `godence:"active"`
+		Tags   []username `godence:"tags"`
+	}
+
+	t.Run("basic types", func(t *testing.T) {
+		assert := assert.New(t)
+
+		var name username
+		assert.NoError(ToGo(cadence.String("LemonNeko"), &name))
+		assert.Equal(username("LemonNeko"), name)
+
+		var address username
+		assert.NoError(ToGo(cadence.BytesToAddress([]byte{0xf8, 0xd6, 0xe0, 0x58, 0x6b, 0x0a, 0x20, 0xc7}), &address))
+		assert.Equal(username("0xf8d6e0586b0a20c7"), address)
+
+		var p price
+		assert.NoError(ToGo(cadence.UFix64(50000000), &p))
+		assert.Equal(price(0.5), p)
+
+		var f flag
+		assert.NoError(ToGo(cadence.NewBool(true), &f))
+		assert.Equal(flag(true), f)
+	})
+
+	t.Run("struct fields", func(t *testing.T) {
+		assert := assert.New(t)
+		value := cadence.NewStruct([]cadence.Value{
+			cadence.String("LemonNeko"),
+			cadence.NewUInt8(15),
+			cadence.NewOptional(cadence.UFix64(50000000)),
+			cadence.NewBool(true),
+			cadence.NewArray([]cadence.Value{cadence.String("cat")}),
+		}).WithType(&cadence.StructType{
+			QualifiedIdentifier: "Profile",
+			Fields: []cadence.Field{
+				{Identifier: "name", Type: cadence.StringType{}},
+				{Identifier: "score", Type: cadence.UInt8Type{}},
+				{Identifier: "price", Type: cadence.OptionalType{Type: cadence.UFix64Type{}}},
+				{Identifier: "active", Type: cadence.BoolType{}},
+				{Identifier: "tags", Type: cadence.VariableSizedArrayType{ElementType: cadence.StringType{}}},
+			},
+		})
+
+		dist := profile{}
+		assert.NoError(ToGo(value, &dist))
+		p := price(0.5)
+		assert.Equal(profile{Name: "LemonNeko", Score: 15, Price: &p, Active: true, Tags: []username{"cat"}}, dist)
+	})
+
+	t.Run("type mismatched", func(t *testing.T) {
+		assert := assert.New(t)
+		var name username
+		err := ToGo(cadence.NewBool(true), &name)
+		assert.EqualError(err, "cannot convert Bool to godence.username")
+		assert.ErrorIs(err, ErrTypeMismatch)
+	})
+}
@@ -15,7 +15,7 @@ import (
 )
 
 // Helper for unsupport type test.
-type unsupportType string
+type unsupportType complex128
 
 var flowCli *flowGrpc.Client
 